@@ -0,0 +1,174 @@
+package version
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+// A clause of an advisory range with an optional operator, e.g. ">= 1.0.0.RELEASE"
+var advisoryClauseRegexp = sync.OnceValue(func() *regexp.Regexp {
+	return regexp.MustCompile(`^(===|==|!=|<=|>=|~=|<|>|=)?\s*([^\s<>=!~]\S*)$`)
+})
+
+// Fallback is a fallback applied by ParseAdvisoryRange to a range which isn't valid PEP 440 specifiers.
+type Fallback int
+
+const (
+	// FallbackLenient repairs versions like ParseLenient, e.g. 1.0.0.RELEASE into 1.0.0.
+	FallbackLenient Fallback = iota + 1
+	// FallbackLegacy compares versions which can't be repaired in the legacy order like CompareAny, e.g. 2004d.
+	FallbackLegacy
+)
+
+// String returns the name of the fallback, e.g. "lenient".
+func (f Fallback) String() string {
+	switch f {
+	case FallbackLenient:
+		return "lenient"
+	case FallbackLegacy:
+		return "legacy"
+	}
+	return "unknown"
+}
+
+// AdvisoryRange is a range of affected versions of an advisory, as parsed by ParseAdvisoryRange.
+type AdvisoryRange struct {
+	// Raw is the range as written in the advisory.
+	Raw string
+	// Specifiers are the specifiers of the range. They are empty if FallbackLegacy is applied.
+	Specifiers Specifiers
+	// Fallbacks are the fallbacks applied to parse the range in the order they were applied. It is empty if the range
+	// is valid PEP 440 specifiers.
+	Fallbacks []Fallback
+
+	legacy [][]legacyClause
+}
+
+// legacyClause is a clause compared in the legacy order.
+type legacyClause struct {
+	op       Operator
+	version  AnyVersion
+	wildcard bool
+}
+
+// ParseAdvisoryRange parses a range of affected versions of an advisory feed, which often mixes PEP 440 with other
+// forms. If the range isn't valid PEP 440 specifiers, it applies the fallbacks to each clause and records them:
+// repairing the version like ParseLenient, and otherwise comparing it in the legacy order. A clause without
+// an operator or with "=" means "==". It returns ErrInvalidSpecifier only if no fallback applies, e.g. for
+// a clause with an unknown operator, or for ~= and wildcards with legacy versions.
+func ParseAdvisoryRange(s string, opts ...SpecifierOption) (AdvisoryRange, error) {
+	r := AdvisoryRange{Raw: s}
+	var err error
+	if r.Specifiers, err = NewSpecifiers(s, opts...); err == nil {
+		return r, nil
+	}
+
+	var groups []string
+	var legacy [][]legacyClause
+	for _, g := range strings.Split(s, "||") {
+		var clauses []string
+		var legacyGroup []legacyClause
+		for _, clause := range strings.Split(g, ",") {
+			m := advisoryClauseRegexp().FindStringSubmatch(strings.TrimSpace(clause))
+			if m == nil {
+				return AdvisoryRange{}, xerrors.Errorf("advisory range %q: clause %q: %w", s, clause, ErrInvalidSpecifier)
+			}
+			op := Operator(m[1])
+			if op == "" || op == "=" {
+				op = OpEqual
+			}
+
+			spec := m[2]
+			c := legacyClause{op: op}
+			if prefix, ok := strings.CutSuffix(spec, ".*"); ok {
+				// Wildcards are kept as they are, and can't be compared in the legacy order
+				c.wildcard = true
+				if _, err := Parse(prefix); err != nil {
+					c.version = ParseLegacy(spec)
+					r.addFallback(FallbackLegacy)
+				}
+			} else if v, err := Parse(spec); err == nil {
+				c.version = v
+			} else if v, _, err := ParseLenient(spec); err == nil {
+				c.version = v
+				spec = v.String()
+				r.addFallback(FallbackLenient)
+			} else {
+				c.version = ParseLegacy(spec)
+				r.addFallback(FallbackLegacy)
+			}
+			clauses = append(clauses, string(op)+spec)
+			legacyGroup = append(legacyGroup, c)
+		}
+		groups = append(groups, strings.Join(clauses, ","))
+		legacy = append(legacy, legacyGroup)
+	}
+
+	if !slices.Contains(r.Fallbacks, FallbackLegacy) {
+		if r.Specifiers, err = NewSpecifiers(strings.Join(groups, "||"), opts...); err != nil {
+			return AdvisoryRange{}, xerrors.Errorf("advisory range %q: %w", s, err)
+		}
+		return r, nil
+	}
+
+	for _, c := range slices.Concat(legacy...) {
+		switch {
+		case c.wildcard:
+			return AdvisoryRange{}, xerrors.Errorf("advisory range %q: wildcards with legacy versions: %w", s,
+				ErrInvalidSpecifier)
+		case c.op == OpCompatible || c.op == OpArbitrary:
+			return AdvisoryRange{}, xerrors.Errorf("advisory range %q: %s with legacy versions: %w", s, c.op,
+				ErrInvalidSpecifier)
+		}
+	}
+	r.legacy = legacy
+	return r, nil
+}
+
+func (r *AdvisoryRange) addFallback(f Fallback) {
+	if !slices.Contains(r.Fallbacks, f) {
+		r.Fallbacks = append(r.Fallbacks, f)
+	}
+}
+
+// Check reports whether the version is in the range. A LegacyVersion is only in a range parsed with FallbackLegacy.
+func (r AdvisoryRange) Check(v AnyVersion) bool {
+	if r.legacy == nil {
+		ver, ok := v.(Version)
+		return ok && r.Specifiers.Check(ver)
+	}
+
+	return slices.ContainsFunc(r.legacy, func(group []legacyClause) bool {
+		for _, c := range group {
+			cmp := CompareAny(v, c.version)
+			var ok bool
+			switch c.op {
+			case OpEqual:
+				ok = cmp == 0
+			case OpNotEqual:
+				ok = cmp != 0
+			case OpLessThan:
+				ok = cmp < 0
+			case OpLessThanEqual:
+				ok = cmp <= 0
+			case OpGreaterThan:
+				ok = cmp > 0
+			case OpGreaterThanEqual:
+				ok = cmp >= 0
+			}
+			if !ok {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// String returns the range as written in the advisory.
+func (r AdvisoryRange) String() string {
+	return r.Raw
+}
@@ -0,0 +1,103 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAdvisoryRange(t *testing.T) {
+	tests := []struct {
+		name          string
+		s             string
+		wantSpecifier string
+		wantFallbacks []Fallback
+		affected      []string
+		unaffected    []string
+		wantErr       error
+	}{
+		{
+			name:          "PEP 440",
+			s:             ">=1.0, <1.4",
+			wantSpecifier: ">=1.0,<1.4",
+			affected:      []string{"1.0", "1.3.2"},
+			unaffected:    []string{"0.9", "1.4"},
+		},
+		{
+			name:          "lenient",
+			s:             ">=1.0.0.RELEASE, <1.2.0-SNAPSHOT",
+			wantSpecifier: ">=1.0.0,<1.2.0.dev0",
+			wantFallbacks: []Fallback{FallbackLenient},
+			affected:      []string{"1.0", "1.1.9"},
+			unaffected:    []string{"0.9", "1.2.0"},
+		},
+		{
+			name:          "lenient with a wildcard",
+			s:             "==1.0.*||=2.0.0-final",
+			wantSpecifier: "==1.0.*||==2.0.0",
+			wantFallbacks: []Fallback{FallbackLenient},
+			affected:      []string{"1.0.3", "2.0"},
+			unaffected:    []string{"1.1", "2.0.1"},
+		},
+		{
+			name:          "legacy",
+			s:             ">=1.0-p1, <1.0-p3",
+			wantFallbacks: []Fallback{FallbackLegacy},
+			affected:      []string{"1.0-p1", "1.0-p2"},
+			unaffected:    []string{"1.0-p0", "1.0-p3", "1.0"},
+		},
+		{
+			name:          "legacy and lenient",
+			s:             "<1.0-p3 || >=1.0.0.RELEASE, !=1.1",
+			wantFallbacks: []Fallback{FallbackLegacy, FallbackLenient},
+			affected:      []string{"1.0-p2", "1.0", "1.2"},
+			unaffected:    []string{"1.0-p3", "1.1"},
+		},
+		{
+			name:    "unknown operator",
+			s:       "=>1.0, <<2.0",
+			wantErr: ErrInvalidSpecifier,
+		},
+		{
+			name:    "compatible release with legacy versions",
+			s:       "~=1.0-p1",
+			wantErr: ErrInvalidSpecifier,
+		},
+		{
+			name:    "wildcard with legacy versions",
+			s:       "==1.0.*, !=1.0-p1",
+			wantErr: ErrInvalidSpecifier,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAdvisoryRange(tt.s)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.s, got.String())
+			assert.Equal(t, tt.wantFallbacks, got.Fallbacks)
+			if tt.wantSpecifier != "" {
+				assert.Equal(t, tt.wantSpecifier, got.Specifiers.String())
+			}
+			for _, v := range tt.affected {
+				assert.True(t, got.Check(ParseAny(v)), v)
+			}
+			for _, v := range tt.unaffected {
+				assert.False(t, got.Check(ParseAny(v)), v)
+			}
+		})
+	}
+}
+
+func TestAdvisoryRange_Check_Legacy(t *testing.T) {
+	r, err := ParseAdvisoryRange(">=1.0")
+	require.NoError(t, err)
+
+	// Legacy versions are only in ranges with legacy versions
+	assert.False(t, r.Check(ParseLegacy("1.0-p1")))
+}
@@ -0,0 +1,204 @@
+package version
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+// The binary format of Version is:
+//
+//	format version (1 byte)
+//	epoch (uvarint)
+//	number of release components (uvarint), followed by each component (uvarint)
+//	optional tagged segments in this order:
+//	  binaryTagPre (1 byte), phase (1 byte), number (uvarint)
+//	  binaryTagPost (1 byte), number (uvarint)
+//	  binaryTagDev (1 byte), number (uvarint)
+//	  binaryTagLocal (1 byte), length (uvarint), label (bytes)
+const (
+	binaryFormatVersion byte = 1
+
+	binaryTagPre   byte = 1
+	binaryTagPost  byte = 2
+	binaryTagDev   byte = 3
+	binaryTagLocal byte = 4
+)
+
+// MarshalBinary implements [encoding.BinaryMarshaler].
+func (v Version) MarshalBinary() ([]byte, error) {
+	if v.infinity != 0 {
+		return nil, xerrors.Errorf("marshaling %s: %w", v, ErrInvalidVersion)
+	}
+	buf := make([]byte, 0, 2+binary.MaxVarintLen64*(len(v.release)+2))
+	buf = append(buf, binaryFormatVersion)
+	buf = binary.AppendUvarint(buf, uint64(v.epoch))
+	buf = binary.AppendUvarint(buf, uint64(len(v.release)))
+	for _, r := range v.release {
+		buf = binary.AppendUvarint(buf, uint64(r))
+	}
+
+	if !v.pre.isNull() {
+		buf = append(buf, binaryTagPre, byte(preReleasePhases[v.pre.letter]))
+		buf = binary.AppendUvarint(buf, uint64(v.pre.number))
+	}
+	if !v.post.isNull() {
+		buf = append(buf, binaryTagPost)
+		buf = binary.AppendUvarint(buf, uint64(v.post.number))
+	}
+	if !v.dev.isNull() {
+		buf = append(buf, binaryTagDev)
+		buf = binary.AppendUvarint(buf, uint64(v.dev.number))
+	}
+	if v.local != "" {
+		buf = append(buf, binaryTagLocal)
+		buf = binary.AppendUvarint(buf, uint64(len(v.local)))
+		buf = append(buf, v.local...)
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary implements [encoding.BinaryUnmarshaler].
+func (v *Version) UnmarshalBinary(data []byte) error {
+	d := binaryDecoder{data: data}
+	if format := d.byte(); d.err == nil && format != binaryFormatVersion {
+		return xerrors.Errorf("unsupported binary format version: %d", format)
+	}
+
+	epoch := d.uvarint()
+	release := make([]part.Uint64, min(d.uvarint(), uint64(len(data))))
+	for i := range release {
+		release[i] = part.Uint64(d.uvarint())
+	}
+
+	var pre, post, dev letterNumber
+	var local string
+	for d.err == nil && len(d.data) > 0 {
+		switch tag := d.byte(); tag {
+		case binaryTagPre:
+			phase := PreReleasePhase(d.byte())
+			if phase.String() == "" {
+				return xerrors.Errorf("invalid pre-release phase: %d", phase)
+			}
+			pre = letterNumber{letter: preReleaseAliases[phase.String()], number: part.Uint64(d.uvarint())}
+		case binaryTagPost:
+			post = letterNumber{letter: postQualifier, number: part.Uint64(d.uvarint())}
+		case binaryTagDev:
+			dev = letterNumber{letter: devQualifier, number: part.Uint64(d.uvarint())}
+		case binaryTagLocal:
+			local = string(d.bytes(d.uvarint()))
+			if d.err == nil && !localRegex().MatchString(local) {
+				return xerrors.Errorf("invalid local version (%s): %w", local, ErrInvalidVersion)
+			}
+		default:
+			return xerrors.Errorf("unknown binary tag: %d", tag)
+		}
+	}
+	if d.err != nil {
+		return d.err
+	}
+
+	if len(release) == 0 {
+		*v = Version{}
+		return nil
+	}
+
+	*v = newVersion(part.Uint64(epoch), release, pre, post, dev, local)
+	v.original = v.String()
+	return nil
+}
+
+type binaryDecoder struct {
+	data []byte
+	err  error
+}
+
+var errTruncated = xerrors.New("truncated binary version")
+
+func (d *binaryDecoder) byte() byte {
+	if d.err != nil {
+		return 0
+	} else if len(d.data) == 0 {
+		d.err = errTruncated
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return b
+}
+
+func (d *binaryDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	n, size := binary.Uvarint(d.data)
+	if size <= 0 {
+		d.err = errTruncated
+		return 0
+	}
+	d.data = d.data[size:]
+	return n
+}
+
+func (d *binaryDecoder) bytes(n uint64) []byte {
+	if d.err != nil {
+		return nil
+	} else if uint64(len(d.data)) < n {
+		d.err = errTruncated
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+const (
+	specifiersFlagPreRelease     byte = 1 << 0
+	specifiersFlagPreReleaseDeny byte = 1 << 1
+	specifiersFlagPreReleaseAuto byte = 1 << 2
+)
+
+// GobEncode implements [encoding/gob.GobEncoder]. Unlike MarshalText, the options given to NewSpecifiers are kept.
+// The format is the format version (1 byte), option flags (1 byte) and the specifiers string.
+func (ss Specifiers) GobEncode() ([]byte, error) {
+	var flags byte
+	switch ss.conf.preRelease {
+	case PreReleaseAllow:
+		flags |= specifiersFlagPreRelease
+	case PreReleaseDeny:
+		flags |= specifiersFlagPreReleaseDeny
+	case PreReleaseAuto:
+		flags |= specifiersFlagPreReleaseAuto
+	}
+	return append([]byte{binaryFormatVersion, flags}, ss.String()...), nil
+}
+
+// GobDecode implements [encoding/gob.GobDecoder].
+func (ss *Specifiers) GobDecode(data []byte) error {
+	if len(data) < 2 {
+		return xerrors.New("truncated binary specifiers")
+	} else if data[0] != binaryFormatVersion {
+		return xerrors.Errorf("unsupported binary format version: %d", data[0])
+	}
+
+	if len(data) == 2 {
+		*ss = Specifiers{}
+	} else {
+		var err error
+		if *ss, err = NewSpecifiers(string(data[2:])); err != nil {
+			return err
+		}
+	}
+	switch {
+	case data[1]&specifiersFlagPreRelease != 0:
+		ss.conf.preRelease = PreReleaseAllow
+	case data[1]&specifiersFlagPreReleaseDeny != 0:
+		ss.conf.preRelease = PreReleaseDeny
+	case data[1]&specifiersFlagPreReleaseAuto != 0:
+		ss.conf.preRelease = PreReleaseAuto
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package version_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+func TestVersion_Binary(t *testing.T) {
+	for _, v := range append(versions, "0", "18446744073709551615.0", "1!2.0b0.post0.dev0+ubuntu-1_a") {
+		t.Run(v, func(t *testing.T) {
+			want := version.MustParse(version.MustParse(v).String())
+
+			data, err := want.MarshalBinary()
+			require.NoError(t, err)
+
+			var got version.Version
+			require.NoError(t, got.UnmarshalBinary(data))
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("compact", func(t *testing.T) {
+		data, err := version.MustParse("1.2.3").MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 0, 3, 1, 2, 3}, data)
+	})
+
+	t.Run("Zero Value", func(t *testing.T) {
+		data, err := version.Version{}.MarshalBinary()
+		require.NoError(t, err)
+
+		got := version.MustParse("1.0")
+		require.NoError(t, got.UnmarshalBinary(data))
+		assert.Equal(t, version.Version{}, got)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, data := range [][]byte{
+			nil,
+			{2, 0, 1, 1},                 // unsupported format
+			{1, 0, 3, 1, 2},              // truncated release
+			{1, 0, 1, 1, 9},              // unknown tag
+			{1, 0, 1, 1, 1, 7, 1},        // invalid phase
+			{1, 0, 1, 1, 4, 5, 'a'},      // truncated local
+			{1, 0, 1, 1, 4, 2, 'a', '+'}, // invalid local
+		} {
+			var got version.Version
+			assert.Error(t, got.UnmarshalBinary(data), data)
+		}
+	})
+}
+
+func TestGob(t *testing.T) {
+	type advisory struct {
+		Affected   version.Specifiers
+		Unaffected version.Specifiers
+		Fixed      version.Version
+		Versions   []version.Version
+	}
+
+	affected, err := version.NewSpecifiers(">=1.0,<1.4.2 || ==2.0", version.WithPreRelease(true))
+	require.NoError(t, err)
+	unaffected, err := version.NewSpecifiers("<1.0")
+	require.NoError(t, err)
+
+	want := advisory{
+		Affected:   affected,
+		Unaffected: unaffected,
+		Fixed:      version.MustParse("1!1.4.2rc1.post2.dev3+local"),
+		Versions:   []version.Version{version.MustParse("1.0"), version.MustParse("2.0")},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(want))
+
+	var got advisory
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&got))
+	assert.Equal(t, want.Affected.String(), got.Affected.String())
+	assert.Equal(t, want.Unaffected.String(), got.Unaffected.String())
+	assert.Equal(t, want.Fixed, got.Fixed)
+	assert.Equal(t, want.Versions, got.Versions)
+
+	// The pre-release option must be kept
+	assert.True(t, got.Affected.Check(version.MustParse("1.4.2a1")))
+	assert.False(t, got.Unaffected.Check(version.MustParse("1.0a1")))
+
+	policies := []version.PreReleasePolicy{version.PreReleaseDefault, version.PreReleaseAllow,
+		version.PreReleaseDeny, version.PreReleaseAuto}
+	for _, p := range policies {
+		ss, err := version.NewSpecifiers(">=1.0", version.WithPreReleasePolicy(p))
+		require.NoError(t, err)
+		b, err := ss.GobEncode()
+		require.NoError(t, err)
+
+		var got version.Specifiers
+		require.NoError(t, got.GobDecode(b))
+		assert.True(t, ss.Equal(got), p)
+	}
+}
@@ -0,0 +1,73 @@
+package version
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache memoizes Parse by the input string, so that a version parsed repeatedly is parsed only once.
+// The returned versions are shared between callers, which is safe since Version is immutable.
+// Malformed versions are cached as well. A Cache is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List // front is the most recently used
+}
+
+type cacheEntry struct {
+	input   string
+	version Version
+	err     error
+}
+
+// NewCache returns a Cache holding up to size versions, evicting the least recently used one when full.
+// If size is zero or negative, the cache is unbounded.
+func NewCache(size int) *Cache {
+	return &Cache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Parse is like the package-level Parse, but returns the cached result if v has been parsed before.
+func (c *Cache) Parse(v string) (Version, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[v]; ok {
+		c.lru.MoveToFront(e)
+		entry := e.Value.(*cacheEntry)
+		c.mu.Unlock()
+		return entry.version, entry.err
+	}
+	c.mu.Unlock()
+
+	// Parse without holding the lock so that other lookups aren't blocked.
+	ver, err := Parse(v)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another goroutine may have parsed the same version meanwhile.
+	if e, ok := c.entries[v]; ok {
+		c.lru.MoveToFront(e)
+		entry := e.Value.(*cacheEntry)
+		return entry.version, entry.err
+	}
+
+	c.entries[v] = c.lru.PushFront(&cacheEntry{input: v, version: ver, err: err})
+	if c.size > 0 && c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).input)
+	}
+
+	return ver, err
+}
+
+// Len returns the number of cached versions.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
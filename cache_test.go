@@ -0,0 +1,74 @@
+package version_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+func TestCache_Parse(t *testing.T) {
+	c := version.NewCache(2)
+
+	got, err := c.Parse("v1.0-1")
+	require.NoError(t, err)
+	assert.Equal(t, version.MustParse("v1.0-1"), got)
+
+	got, err = c.Parse("v1.0-1")
+	require.NoError(t, err)
+	assert.Equal(t, version.MustParse("v1.0-1"), got)
+	assert.Equal(t, 1, c.Len())
+
+	_, err = c.Parse("french toast")
+	assert.ErrorIs(t, err, version.ErrInvalidVersion)
+	_, err = c.Parse("french toast")
+	assert.ErrorIs(t, err, version.ErrInvalidVersion)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestCache_Eviction(t *testing.T) {
+	c := version.NewCache(2)
+	for _, v := range []string{"1.0", "2.0", "1.0", "3.0"} {
+		_, err := c.Parse(v)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, c.Len())
+
+	unbounded := version.NewCache(0)
+	for _, v := range []string{"1.0", "2.0", "3.0"} {
+		_, err := unbounded.Parse(v)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, unbounded.Len())
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	c := version.NewCache(8)
+	inputs := []string{"1.0", "1.0a1", "1!2.0", "2.0.post1", "3.0.dev1", "4.0+local"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, v := range inputs {
+				got, err := c.Parse(v)
+				assert.NoError(t, err)
+				assert.Equal(t, version.MustParse(v).String(), got.String())
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, len(inputs), c.Len())
+}
+
+func BenchmarkCache_Parse(b *testing.B) {
+	c := version.NewCache(128)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = c.Parse("1!2.3.4rc5.post6.dev7+ubuntu-1")
+	}
+}
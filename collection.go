@@ -0,0 +1,235 @@
+package version
+
+import (
+	"container/heap"
+	"slices"
+
+	"golang.org/x/xerrors"
+)
+
+// Collection is a type that implements the sort.Interface interface
+// so that versions can be sorted.
+type Collection []Version
+
+func (c Collection) Len() int {
+	return len(c)
+}
+
+func (c Collection) Less(i, j int) bool {
+	return c[i].LessThan(c[j])
+}
+
+func (c Collection) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+// Sort sorts the versions in ascending order in place.
+func (c Collection) Sort() {
+	slices.SortFunc(c, Compare)
+}
+
+// SortWith sorts the versions in ascending order in place, with the ordering adjusted by the given options like
+// CompareWith, e.g. WithOriginalTiebreak(true) for an order reproducible across runs.
+func (c Collection) SortWith(opts ...CompareOption) {
+	slices.SortFunc(c, func(a, b Version) int {
+		return CompareWith(a, b, opts...)
+	})
+}
+
+// SortDesc sorts the versions in descending order in place, so that the latest version comes first.
+func (c Collection) SortDesc() {
+	slices.SortFunc(c, func(a, b Version) int {
+		return Compare(b, a)
+	})
+}
+
+// Contains reports whether the collection has a version equal to v, e.g. 1.0.0 for 1.0.
+func (c Collection) Contains(v Version) bool {
+	return slices.ContainsFunc(c, v.Equal)
+}
+
+// Max returns the greatest of the versions, or the zero Version if there are none.
+// Of equal versions like 1.0 and 1.0.0, it returns the first one.
+func Max(versions ...Version) Version {
+	if len(versions) == 0 {
+		return Version{}
+	}
+	return slices.MaxFunc(versions, Compare)
+}
+
+// Min returns the least of the versions, or the zero Version if there are none.
+// Of equal versions like 1.0 and 1.0.0, it returns the first one.
+func Min(versions ...Version) Version {
+	if len(versions) == 0 {
+		return Version{}
+	}
+	return slices.MinFunc(versions, Compare)
+}
+
+// Latest parses the versions and returns the greatest one, including pre-releases. It returns the error of ParseAll
+// if a version isn't valid, and ErrNoVersions if there are none.
+func Latest(vs []string) (Version, error) {
+	versions, err := ParseAll(vs)
+	if err != nil {
+		return Version{}, err
+	} else if len(versions) == 0 {
+		return Version{}, xerrors.Errorf("latest version: %w", ErrNoVersions)
+	}
+	return Max(versions...), nil
+}
+
+// Unique returns the versions without the duplicates under PEP 440 equality, keeping the first of equal versions in
+// their order, e.g. 1.0 and 2.0 for 1.0, 2.0 and 1.0.0. The options adjust the equality like CompareWith, e.g.
+// WithoutLocal(true) makes 1.0+local a duplicate of 1.0.
+func Unique(versions []Version, opts ...CompareOption) []Version {
+	order := make([]int, len(versions))
+	for i := range order {
+		order[i] = i
+	}
+	compare := func(i, j int) int {
+		return CompareWith(versions[i], versions[j], opts...)
+	}
+	slices.SortStableFunc(order, compare)
+
+	keep := make([]bool, len(versions))
+	for k, i := range order {
+		keep[i] = k == 0 || compare(order[k-1], i) != 0
+	}
+	unique := make([]Version, 0, len(versions))
+	for i, v := range versions {
+		if keep[i] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// TopK returns the k greatest versions in descending order, or all of them if there are fewer, without sorting all
+// the versions. Of equal versions at the boundary, it's unspecified which ones are returned.
+func TopK(versions []Version, k int) []Version {
+	if k <= 0 {
+		return nil
+	}
+
+	// A min-heap of the greatest versions so far, with the least of them at the root
+	h := make(versionHeap, 0, min(k, len(versions)))
+	for _, v := range versions {
+		switch {
+		case len(h) < k:
+			heap.Push(&h, v)
+		case v.GreaterThan(h[0]):
+			h[0] = v
+			heap.Fix(&h, 0)
+		}
+	}
+	Collection(h).SortDesc()
+	return h
+}
+
+// versionHeap implements heap.Interface with the least version at the root.
+type versionHeap []Version
+
+func (h versionHeap) Len() int           { return len(h) }
+func (h versionHeap) Less(i, j int) bool { return h[i].LessThan(h[j]) }
+func (h versionHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *versionHeap) Push(x any) {
+	*h = append(*h, x.(Version))
+}
+
+func (h *versionHeap) Pop() any {
+	old := *h
+	v := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return v
+}
+
+// SortStrings sorts the versions in PEP 440 order in place, keeping the order of equal ones like 1.0 and 1.0.0.
+// If a version isn't valid, it leaves vs unchanged and returns the error of ParseAll.
+func SortStrings(vs []string) error {
+	versions, err := ParseAll(vs)
+	if err != nil {
+		return err
+	}
+	sortStrings(vs, versions, nil)
+	return nil
+}
+
+// SortStringsLenient is like SortStrings, but parses the versions with ParseLenient and moves the ones which still
+// aren't valid to the end, keeping their order.
+func SortStringsLenient(vs []string) {
+	versions := make([]Version, len(vs))
+	valid := make([]bool, len(vs))
+	for i, s := range vs {
+		v, _, err := ParseLenient(s)
+		versions[i], valid[i] = v, err == nil
+	}
+	sortStrings(vs, versions, valid)
+}
+
+// sortStrings sorts vs by their parsed versions. If valid is non-nil, the invalid versions are moved to the end.
+func sortStrings(vs []string, versions []Version, valid []bool) {
+	type entry struct {
+		s       string
+		v       Version
+		invalid bool
+	}
+	entries := make([]entry, len(vs))
+	for i, s := range vs {
+		entries[i] = entry{s: s, v: versions[i], invalid: valid != nil && !valid[i]}
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		if a.invalid || b.invalid {
+			return compareFlags(a.invalid, b.invalid)
+		}
+		return Compare(a.v, b.v)
+	})
+	for i, e := range entries {
+		vs[i] = e.s
+	}
+}
+
+type latestConf struct {
+	preReleaseFallback bool
+}
+
+type LatestOption interface {
+	apply(*latestConf)
+}
+
+// WithPreReleaseFallback makes LatestStable and FirstUnaffected select a pre-release or development release if there
+// are no final releases to select, like pip does when only pre-releases satisfy a requirement.
+type WithPreReleaseFallback bool
+
+func (o WithPreReleaseFallback) apply(c *latestConf) {
+	c.preReleaseFallback = bool(o)
+}
+
+// LatestAny returns the greatest of the versions, including pre-releases and development releases.
+// ok is false if there are no versions.
+func LatestAny(versions []Version) (latest Version, ok bool) {
+	if len(versions) == 0 {
+		return Version{}, false
+	}
+	return Max(versions...), true
+}
+
+// LatestStable returns the greatest final release of the versions, including post-releases but neither
+// pre-releases nor development releases. ok is false if there are no such versions, unless WithPreReleaseFallback
+// is set and there are other versions.
+func LatestStable(versions []Version, opts ...LatestOption) (latest Version, ok bool) {
+	var c latestConf
+	for _, o := range opts {
+		o.apply(&c)
+	}
+
+	for _, v := range versions {
+		if !v.IsPreRelease() && (!ok || v.GreaterThan(latest)) {
+			latest, ok = v, true
+		}
+	}
+	if !ok && c.preReleaseFallback {
+		return LatestAny(versions)
+	}
+	return latest, ok
+}
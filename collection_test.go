@@ -0,0 +1,257 @@
+package version_test
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+func TestCollection(t *testing.T) {
+	raw := []string{"1.1.1", "1.0", "1.0rc1", "2!0.1", "1.0.post1", "0.7.1", "1.0.dev1", "1.0+local"}
+	want := []string{"0.7.1", "1.0.dev1", "1.0rc1", "1.0", "1.0+local", "1.0.post1", "1.1.1", "2!0.1"}
+
+	toStrings := func(c version.Collection) []string {
+		got := make([]string, len(c))
+		for i, v := range c {
+			got[i] = v.String()
+		}
+		return got
+	}
+	parse := func() version.Collection {
+		versions, err := version.ParseAll(raw)
+		require.NoError(t, err)
+		return versions
+	}
+
+	t.Run("sort.Sort", func(t *testing.T) {
+		c := parse()
+		sort.Sort(c)
+		assert.Equal(t, want, toStrings(c))
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		c := parse()
+		c.Sort()
+		assert.Equal(t, want, toStrings(c))
+	})
+
+	t.Run("SortDesc", func(t *testing.T) {
+		c := parse()
+		c.SortDesc()
+		assert.Equal(t, []string{"2!0.1", "1.1.1", "1.0.post1", "1.0+local", "1.0", "1.0rc1", "1.0.dev1", "0.7.1"}, toStrings(c))
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		c := parse()
+		assert.True(t, c.Contains(version.MustParse("1.1.1")))
+		assert.True(t, c.Contains(version.MustParse("0.7.1.0")))
+		assert.False(t, c.Contains(version.MustParse("1.1")))
+		assert.False(t, version.Collection(nil).Contains(version.MustParse("1.0")))
+	})
+}
+
+func TestSortStrings(t *testing.T) {
+	vs := []string{"1.10", "1.0.0", "1.2rc1", "1.0", "v1.2", "0.9"}
+	require.NoError(t, version.SortStrings(vs))
+	assert.Equal(t, []string{"0.9", "1.0.0", "1.0", "1.2rc1", "v1.2", "1.10"}, vs)
+
+	invalid := []string{"1.10", "latest", "1.2"}
+	err := version.SortStrings(invalid)
+	require.ErrorIs(t, err, version.ErrInvalidVersion)
+	assert.Equal(t, []string{"1.10", "latest", "1.2"}, invalid)
+}
+
+func TestSortStringsLenient(t *testing.T) {
+	vs := []string{"latest", "1.10", "1.0-SNAPSHOT", "main", "1.0.0.RELEASE", "1.2"}
+	version.SortStringsLenient(vs)
+	assert.Equal(t, []string{"1.0-SNAPSHOT", "1.0.0.RELEASE", "1.2", "1.10", "latest", "main"}, vs)
+}
+
+func TestMaxMin(t *testing.T) {
+	versions, err := version.ParseAll([]string{"1.0", "2.0rc1", "1.0.0", "0.9.post1", "1.10"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "2.0rc1", version.Max(versions...).String())
+	assert.Equal(t, "0.9.post1", version.Min(versions...).String())
+	assert.Equal(t, "1.0", version.Max(versions[0], versions[2]).String())
+	assert.Equal(t, "1.0", version.Min(versions[0], versions[2]).String())
+	assert.Equal(t, version.Version{}, version.Max())
+	assert.Equal(t, version.Version{}, version.Min())
+}
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		name    string
+		vs      []string
+		want    string
+		wantErr error
+	}{
+		{name: "releases", vs: []string{"1.2", "1.10", "1.9.post1"}, want: "1.10"},
+		{name: "pre-release", vs: []string{"1.2", "1.3.dev1"}, want: "1.3.dev1"},
+		{name: "invalid", vs: []string{"1.2", "latest"}, wantErr: version.ErrInvalidVersion},
+		{name: "empty", wantErr: version.ErrNoVersions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := version.Latest(tt.vs)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestLatestStable(t *testing.T) {
+	tests := []struct {
+		name       string
+		versions   []string
+		opts       []version.LatestOption
+		wantStable string
+		wantAny    string
+	}{
+		{
+			name:       "pre-release above final",
+			versions:   []string{"1.9", "2.0rc1", "1.10", "2.0.dev3"},
+			wantStable: "1.10",
+			wantAny:    "2.0rc1",
+		},
+		{
+			name:       "post-release",
+			versions:   []string{"1.0", "1.0.post1", "1.1a1"},
+			wantStable: "1.0.post1",
+			wantAny:    "1.1a1",
+		},
+		{
+			name:     "pre-releases only",
+			versions: []string{"1.0a1", "1.0b2", "0.1.dev0"},
+			wantAny:  "1.0b2",
+		},
+		{
+			name:       "pre-releases only with fallback",
+			versions:   []string{"1.0a1", "1.0b2", "0.1.dev0"},
+			opts:       []version.LatestOption{version.WithPreReleaseFallback(true)},
+			wantStable: "1.0b2",
+			wantAny:    "1.0b2",
+		},
+		{
+			name:       "fallback unused",
+			versions:   []string{"1.0", "2.0a1"},
+			opts:       []version.LatestOption{version.WithPreReleaseFallback(true)},
+			wantStable: "1.0",
+			wantAny:    "2.0a1",
+		},
+		{name: "empty", opts: []version.LatestOption{version.WithPreReleaseFallback(true)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions, err := version.ParseAll(tt.versions)
+			require.NoError(t, err)
+
+			got, ok := version.LatestStable(versions, tt.opts...)
+			assert.Equal(t, tt.wantStable != "", ok)
+			if ok {
+				assert.Equal(t, tt.wantStable, got.String())
+			}
+
+			got, ok = version.LatestAny(versions)
+			assert.Equal(t, tt.wantAny != "", ok)
+			if ok {
+				assert.Equal(t, tt.wantAny, got.String())
+			}
+		})
+	}
+}
+
+func TestUnique(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		opts     []version.CompareOption
+		want     []string
+	}{
+		{
+			name:     "equal releases",
+			versions: []string{"1.0", "2.0", "1.0.0", "1.0+local", "v2.0.0", "1.0rc1"},
+			want:     []string{"1.0", "2.0", "1.0+local", "1.0rc1"},
+		},
+		{
+			name:     "without local",
+			versions: []string{"1.0+local", "2.0", "1.0.0", "1.0", "1.0+other"},
+			opts:     []version.CompareOption{version.WithoutLocal(true)},
+			want:     []string{"1.0+local", "2.0"},
+		},
+		{
+			name:     "without epoch",
+			versions: []string{"1!1.0", "1.0", "2.0"},
+			opts:     []version.CompareOption{version.WithoutEpoch(true)},
+			want:     []string{"1!1.0", "2.0"},
+		},
+		{name: "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions, err := version.ParseAll(tt.versions)
+			require.NoError(t, err)
+
+			var got []string
+			for _, v := range version.Unique(versions, tt.opts...) {
+				got = append(got, v.Original())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTopK(t *testing.T) {
+	versions, err := version.ParseAll([]string{"1.0", "2.0rc1", "0.9", "1.10", "2.0", "1.2.post1", "0.1"})
+	require.NoError(t, err)
+
+	toStrings := func(vs []version.Version) []string {
+		var got []string
+		for _, v := range vs {
+			got = append(got, v.String())
+		}
+		return got
+	}
+	assert.Equal(t, []string{"2.0", "2.0rc1", "1.10"}, toStrings(version.TopK(versions, 3)))
+	assert.Equal(t, []string{"2.0"}, toStrings(version.TopK(versions, 1)))
+	assert.Equal(t, []string{"2.0", "2.0rc1", "1.10", "1.2.post1", "1.0", "0.9", "0.1"}, toStrings(version.TopK(versions, 10)))
+	assert.Empty(t, version.TopK(versions, 0))
+	assert.Empty(t, version.TopK(nil, 3))
+
+	// TopK agrees with a full sort
+	sorted := version.Collection(slices.Clone(versions))
+	sorted.SortDesc()
+	for k := range len(versions) + 1 {
+		assert.Equal(t, toStrings(sorted[:k]), toStrings(version.TopK(versions, k)), "k=%d", k)
+	}
+}
+
+func TestCollection_SortWith(t *testing.T) {
+	raw := []string{"1.0.0", "v1.0", "2.0", "1.0", "1.0+b", "1.0+B", "0.9"}
+	want := []string{"0.9", "1.0", "1.0.0", "v1.0", "1.0+B", "1.0+b", "2.0"}
+
+	// The order doesn't depend on the input order
+	for range 10 {
+		versions, err := version.ParseAll(raw)
+		require.NoError(t, err)
+		c := version.Collection(versions)
+		rand.Shuffle(len(c), c.Swap)
+
+		c.SortWith(version.WithOriginalTiebreak(true))
+		var got []string
+		for _, v := range c {
+			got = append(got, v.Original())
+		}
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,35 @@
+package version
+
+type compareConf struct {
+	ignoreEpoch bool
+	ignoreLocal bool
+	tiebreak    bool
+}
+
+type CompareOption interface {
+	apply(*compareConf)
+}
+
+// WithoutEpoch makes comparisons ignore the epoch segment (e.g. 1!1.0 and 1.0 are equal).
+// Note that this ordering is not defined in PEP 440.
+type WithoutEpoch bool
+
+func (o WithoutEpoch) apply(c *compareConf) {
+	c.ignoreEpoch = bool(o)
+}
+
+// WithoutLocal makes comparisons ignore the local version label (e.g. 1.0+ubuntu1 and 1.0 are equal),
+// like matching with specifiers without a local version does.
+type WithoutLocal bool
+
+func (o WithoutLocal) apply(c *compareConf) {
+	c.ignoreLocal = bool(o)
+}
+
+// WithOriginalTiebreak makes comparisons order equal versions by their original strings (e.g. 1.0 before 1.0.0),
+// so that sorting gives the same order regardless of the input order.
+type WithOriginalTiebreak bool
+
+func (o WithOriginalTiebreak) apply(c *compareConf) {
+	c.tiebreak = bool(o)
+}
@@ -0,0 +1,86 @@
+package version
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// condaOperators are the operators of conda version specs, longest first.
+var condaOperators = []string{"==", "!=", "<=", ">=", "~=", "<", ">", "="}
+
+// ParseConda returns the specifiers of a conda version spec, the version part of a MatchSpec, e.g. "1.2.*",
+// ">=1.0,<2.0a0" or "1.11.1|1.11.3". As in conda, "=1.2" and "1.2*" match every version starting with 1.2, while
+// a bare version like "1.2" or one with a build string like "1.2.3=py36_0" matches the version only. The build string
+// is dropped as specifiers constrain versions only. A conda spec compares versions in their order, so the result uses
+// PreReleaseAllow. It returns ErrUnrepresentable for parentheses and wildcards inside a version like "1.*.3",
+// ErrInvalidSpecifier if the spec is malformed, and ErrInvalidVersion for conda versions which aren't valid PEP 440
+// versions, e.g. "1.1.1k".
+func ParseConda(spec string) (Specifiers, error) {
+	s := strings.TrimSpace(spec)
+	switch {
+	case s == "":
+		return Specifiers{}, xerrors.Errorf("empty conda spec: %w", ErrInvalidSpecifier)
+	case strings.ContainsAny(s, "()"):
+		return Specifiers{}, xerrors.Errorf("conda spec %q with parentheses: %w", spec, ErrUnrepresentable)
+	}
+
+	groups := strings.Split(s, "|")
+	single := len(groups) == 1 && !strings.Contains(s, ",")
+	for i, g := range groups {
+		clauses := strings.Split(g, ",")
+		for j, c := range clauses {
+			var err error
+			if clauses[j], err = condaClause(strings.TrimSpace(c), single); err != nil {
+				return Specifiers{}, xerrors.Errorf("conda spec %q: %w", spec, err)
+			}
+		}
+		groups[i] = strings.Join(clauses, ",")
+	}
+	return NewSpecifiers(strings.Join(groups, "||"), WithPreReleasePolicy(PreReleaseAllow))
+}
+
+// condaClause returns the PEP 440 clause of a conda constraint. A build string is allowed if the constraint is
+// the whole spec.
+func condaClause(c string, single bool) (string, error) {
+	op := ""
+	if i := slices.IndexFunc(condaOperators, func(o string) bool {
+		return strings.HasPrefix(c, o)
+	}); i >= 0 {
+		op = condaOperators[i]
+	}
+	v := strings.TrimSpace(c[len(op):])
+	if single && (op == "" || op == "=") {
+		var build string
+		if v, build, _ = strings.Cut(v, "="); build != "" {
+			op = "=="
+		}
+	}
+
+	wildcard := strings.HasSuffix(v, "*")
+	v = strings.TrimSuffix(strings.TrimSuffix(v, "*"), ".")
+	switch {
+	case v == "" && wildcard && op == "":
+		// Every version, as 0.dev0 is the lowest one
+		return ">=0.dev0", nil
+	case v == "":
+		return "", xerrors.Errorf("constraint %q without a version: %w", c, ErrInvalidSpecifier)
+	case strings.Contains(v, "*"):
+		return "", xerrors.Errorf("constraint %q: %w", c, ErrUnrepresentable)
+	}
+	if _, err := Parse(v); err != nil {
+		return "", xerrors.Errorf("constraint %q: %w", c, err)
+	}
+
+	switch {
+	case op == "" && wildcard, op == "=", op == "==" && wildcard:
+		return "==" + v + ".*", nil
+	case op == "!=" && wildcard:
+		return "!=" + v + ".*", nil
+	case op == "":
+		return "==" + v, nil
+	}
+	// The wildcard is meaningless with the other operators, e.g. ">=1.8*" is ">=1.8"
+	return op + v, nil
+}
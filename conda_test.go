@@ -0,0 +1,51 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConda(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr error
+	}{
+		{spec: "1.2.*", want: "==1.2.*"},
+		{spec: "1.2*", want: "==1.2.*"},
+		{spec: "=1.11", want: "==1.11.*"},
+		{spec: "1.11", want: "==1.11"},
+		{spec: "==1.11", want: "==1.11"},
+		{spec: ">=1.0,<2.0a0", want: ">=1.0,<2.0a0"},
+		{spec: ">=1.8*", want: ">=1.8"},
+		{spec: "!=1.8*", want: "!=1.8.*"},
+		{spec: "~=1.8", want: "~=1.8"},
+		{spec: "1.11.1|1.11.3", want: "==1.11.1||==1.11.3"},
+		{spec: ">=1.8,<2|1.7.*", want: ">=1.8,<2||==1.7.*"},
+		{spec: "1.2.3=py36_0", want: "==1.2.3"},
+		{spec: "=1.2.3=py36_0", want: "==1.2.3"},
+		{spec: " >= 1.0 , < 2.0 ", want: ">=1.0,<2.0"},
+		{spec: "*", want: ">=0.dev0"},
+		{spec: "(>=1.0,<2.0)|3.0", wantErr: ErrUnrepresentable},
+		{spec: "1.*.3", wantErr: ErrUnrepresentable},
+		{spec: "", wantErr: ErrInvalidSpecifier},
+		{spec: ">=", wantErr: ErrInvalidSpecifier},
+		{spec: ">=1.0,", wantErr: ErrInvalidSpecifier},
+		{spec: "1.1.1k", wantErr: ErrInvalidVersion},
+		{spec: ">=1.0,1.2=py36_0", wantErr: ErrInvalidVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseConda(tt.spec)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, PreReleaseAllow, got.conf.preRelease)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	// ErrInvalidVersion is returned when a version is not valid under PEP 440.
+	ErrInvalidVersion = xerrors.New("malformed version")
+
+	// ErrNotCanonical is returned in strict mode when a version is valid but not in its canonical form.
+	ErrNotCanonical = xerrors.New("non-canonical version")
+
+	// ErrInvalidSpecifier is returned when a specifier is not valid.
+	ErrInvalidSpecifier = xerrors.New("improper specifier")
+
+	// ErrLocalNotAllowed is returned when a local version is used with an operator which doesn't allow it.
+	// It also matches ErrInvalidSpecifier.
+	ErrLocalNotAllowed error = &specifierError{"local versions cannot be specified"}
+
+	// ErrWildcardNotAllowed is returned when a wild card is used with an operator or a version which doesn't allow it.
+	// It also matches ErrInvalidSpecifier.
+	ErrWildcardNotAllowed error = &specifierError{"a wild card is not allowed"}
+
+	// ErrMissingOperator is returned when a clause has no operator and WithRequireOperator is set.
+	// It also matches ErrInvalidSpecifier.
+	ErrMissingOperator error = &specifierError{"missing operator"}
+
+	// ErrInputTooLong is returned when a version or specifiers exceed the length set by
+	// WithMaxVersionLength or WithMaxSpecifierLength.
+	ErrInputTooLong = xerrors.New("input too long")
+
+	// ErrTooManyClauses is returned when specifiers have more clauses than set by WithMaxClauses.
+	// It also matches ErrInvalidSpecifier.
+	ErrTooManyClauses error = &specifierError{"too many clauses"}
+
+	// ErrIncompatibleSpecifiers is returned when specifiers with different pre-release policies are combined.
+	ErrIncompatibleSpecifiers = xerrors.New("incompatible specifiers")
+
+	// ErrUnrepresentable is returned when versions can't be written as PEP 440 specifiers,
+	// such as the versions up to every post-release of 2.0.
+	ErrUnrepresentable = xerrors.New("not representable as specifiers")
+
+	// ErrInvalidRequirement is returned when a requirement is not valid, such as one with a malformed project name.
+	ErrInvalidRequirement = xerrors.New("invalid requirement")
+
+	// ErrInvalidFilename is returned when a filename of a distribution is not valid.
+	ErrInvalidFilename = xerrors.New("malformed filename")
+
+	// ErrNoVersions is returned when a version is selected from no versions.
+	ErrNoVersions = xerrors.New("no versions")
+)
+
+// specifierError is a more specific cause of ErrInvalidSpecifier.
+type specifierError struct {
+	msg string
+}
+
+func (e *specifierError) Error() string {
+	return e.msg
+}
+
+func (e *specifierError) Is(target error) bool {
+	return target == ErrInvalidSpecifier
+}
+
+// ParseError describes where a version or a specifier failed to be parsed.
+type ParseError struct {
+	// Input is the whole string being parsed.
+	Input string
+	// Offset is the byte offset of Token in Input.
+	Offset int
+	// Token is the offending part of Input.
+	Token string
+	// Err is the cause, such as ErrInvalidVersion or ErrInvalidSpecifier.
+	Err error
+	// Suggestion is a likely correction of Input, or empty if there is none.
+	Suggestion string
+}
+
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("%s: %v (%q at offset %d)", e.Input, e.Err, e.Token, e.Offset)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", e.Suggestion)
+	}
+	return msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError returns a ParseError pointing at the first whitespace-delimited token
+// after the longest prefix of input matched by prefix.
+func newParseError(input string, prefix *regexp.Regexp, err error) *ParseError {
+	var offset int
+	if loc := prefix.FindStringIndex(input); loc != nil {
+		offset = loc[1]
+	}
+
+	rest := strings.TrimLeftFunc(input[offset:], unicode.IsSpace)
+	offset = len(input) - len(rest)
+
+	token := rest
+	if i := strings.IndexFunc(rest, unicode.IsSpace); i >= 0 {
+		token = rest[:i]
+	}
+
+	return &ParseError{
+		Input:  input,
+		Offset: offset,
+		Token:  token,
+		Err:    err,
+	}
+}
+
+// numberError returns a ParseError for a number which cannot be represented.
+func numberError(input string, offset int, token string, err error) *ParseError {
+	return &ParseError{
+		Input:  input,
+		Offset: offset,
+		Token:  token,
+		Err:    xerrors.Errorf("%v: %w", err, ErrInvalidVersion),
+	}
+}
@@ -0,0 +1,159 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+func TestErrors(t *testing.T) {
+	t.Run("version", func(t *testing.T) {
+		for _, v := range []string{"french toast", "1.0+a+", "99999999999999999999999"} {
+			_, err := version.Parse(v)
+			assert.ErrorIs(t, err, version.ErrInvalidVersion, v)
+		}
+		_, err := version.New(0, nil)
+		assert.ErrorIs(t, err, version.ErrInvalidVersion)
+	})
+
+	t.Run("specifier", func(t *testing.T) {
+		tests := []struct {
+			spec string
+			want []error
+		}{
+			{"=>2.0", []error{version.ErrInvalidSpecifier}},
+			{"~=1", []error{version.ErrInvalidSpecifier}},
+			{">=1.0+deadbeef", []error{version.ErrLocalNotAllowed, version.ErrInvalidSpecifier}},
+			{"~=1.0+5", []error{version.ErrLocalNotAllowed, version.ErrInvalidSpecifier}},
+			{">=1.0.*", []error{version.ErrWildcardNotAllowed, version.ErrInvalidSpecifier}},
+			{"==1.0.dev1.*", []error{version.ErrWildcardNotAllowed, version.ErrInvalidSpecifier}},
+		}
+		for _, tt := range tests {
+			_, err := version.NewSpecifiers(tt.spec)
+			for _, want := range tt.want {
+				assert.ErrorIs(t, err, want, tt.spec)
+			}
+		}
+
+		_, err := version.NewSpecifiers(">=1.0.*")
+		assert.NotErrorIs(t, err, version.ErrLocalNotAllowed)
+	})
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		parse      func(string) error
+		input      string
+		wantOffset int
+		wantToken  string
+		wantErr    error
+	}{
+		{
+			name:       "invalid local version",
+			parse:      parseVersion,
+			input:      "1.0+a+",
+			wantOffset: 5,
+			wantToken:  "+",
+			wantErr:    version.ErrInvalidVersion,
+		},
+		{
+			name:       "trailing garbage",
+			parse:      parseVersion,
+			input:      " 1.0.dev1 foo",
+			wantOffset: 10,
+			wantToken:  "foo",
+			wantErr:    version.ErrInvalidVersion,
+		},
+		{
+			name:       "not a version",
+			parse:      parseVersion,
+			input:      "french toast",
+			wantOffset: 0,
+			wantToken:  "french",
+			wantErr:    version.ErrInvalidVersion,
+		},
+		{
+			name:       "number out of range",
+			parse:      parseVersion,
+			input:      "1.99999999999999999999",
+			wantOffset: 2,
+			wantToken:  "99999999999999999999",
+			wantErr:    version.ErrInvalidVersion,
+		},
+		{
+			name:       "invalid operator",
+			parse:      parseSpecifiers,
+			input:      ">=1.0, =>2.0",
+			wantOffset: 7,
+			wantToken:  "=>2.0",
+			wantErr:    version.ErrInvalidSpecifier,
+		},
+		{
+			name:       "invalid clause in an OR group",
+			parse:      parseSpecifiers,
+			input:      ">=1.0 || <2.0, >=1.0+local",
+			wantOffset: 15,
+			wantToken:  ">=1.0+local",
+			wantErr:    version.ErrLocalNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.parse(tt.input)
+
+			var parseErr *version.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.input, parseErr.Input)
+			assert.Equal(t, tt.wantOffset, parseErr.Offset)
+			assert.Equal(t, tt.wantToken, parseErr.Token)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func parseVersion(s string) error {
+	_, err := version.Parse(s)
+	return err
+}
+
+func parseSpecifiers(s string) error {
+	_, err := version.NewSpecifiers(s)
+	return err
+}
+
+func TestParseError_Suggestion(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string) error
+		input string
+		want  string
+	}{
+		{"semver pre-release", parseVersion, "1.2.3-rc.1.2", "1.2.3rc1"},
+		{"release qualifier", parseVersion, "1.0.0.RELEASE", "1.0.0"},
+		{"no suggestion", parseVersion, "french toast", ""},
+		{"repaired version overflowing", parseVersion, "99999999999999999999999.RELEASE", ""},
+		{"repaired version overflowing in a later number", parseVersion, "1.0.99999999999999999999999-final", ""},
+		{"reversed operator", parseSpecifiers, "=>1.0", ">=1.0"},
+		{"reversed operator in a clause", parseSpecifiers, ">1.0, =<2.0 || ==3.0", ">1.0, <=2.0 || ==3.0"},
+		{"ruby operator", parseSpecifiers, "~>1.2", "~=1.2"},
+		{"repairable version", parseSpecifiers, ">=1.0-SNAPSHOT", ">=1.0.dev0"},
+		{"no suggestion for specifier", parseSpecifiers, "=>foo", ""},
+		{"repaired version overflowing in a specifier", parseSpecifiers, ">=99999999999999999999999.RELEASE", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.parse(tt.input)
+
+			var parseErr *version.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.want, parseErr.Suggestion)
+			if tt.want != "" {
+				assert.Contains(t, err.Error(), "did you mean")
+			}
+		})
+	}
+}
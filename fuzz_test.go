@@ -0,0 +1,104 @@
+package version_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+// worstCaseBound is how long parsing any of the worst-case inputs may take.
+// Parsing is linear in the input length and takes well under a second even with the race detector,
+// so the bound only catches super-linear behavior.
+const worstCaseBound = 10 * time.Second
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"1.0", "1!2.0rc1.post2.dev3+abc.5", "v1.0-1", " 1.0.DEV ", "1.0+", "french toast",
+		"99999999999999999999999.RELEASE"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := version.Parse(s)
+		if version.IsValid(s) != (err == nil) {
+			t.Fatalf("IsValid(%q) disagrees with Parse: %v", s, err)
+		}
+		if err != nil {
+			return
+		}
+
+		// The normalized form must parse back into an equal version
+		n, err := version.Parse(v.String())
+		if err != nil {
+			t.Fatalf("Parse(%q) failed for the normalized form of %q: %v", v.String(), s, err)
+		}
+		if !n.Equal(v) || n.String() != v.String() {
+			t.Fatalf("Parse(%q) = %s, want %s", v.String(), n, v)
+		}
+	})
+}
+
+func FuzzNewSpecifiers(f *testing.F) {
+	for _, s := range []string{">=1.0, <2.0", "~=1.4.5 || ==2.*", "===foo", "=>1.0", "*", ">= 1.0 != 1.3.4.* < 2.0",
+		">=1.0,", ",>=1.0", ">=1.0,,<2.0", "", " || ", "1.0 2.0", "~=1.0.*, >=1.0+local", "\v 1.0"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		ss, err := version.NewSpecifiers(s)
+		if errs := version.ValidateSpecifiers(s); (err == nil) != (len(errs) == 0) {
+			t.Fatalf("ValidateSpecifiers(%q) = %v disagrees with NewSpecifiers: %v", s, errs, err)
+		}
+		if err != nil {
+			return
+		}
+		if _, err = version.NewSpecifiers(ss.String()); err != nil {
+			t.Fatalf("NewSpecifiers(%q) failed for the string form of %q: %v", ss.String(), s, err)
+		}
+	})
+}
+
+func TestParse_WorstCase(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping worst-case inputs in short mode")
+	}
+
+	const n = 1 << 16
+	inputs := []string{
+		"1" + strings.Repeat(".0", n) + "!",
+		"1.0" + strings.Repeat("-", n),
+		"1.0" + strings.Repeat("a", n),
+		"1.0a" + strings.Repeat("1", n) + "x",
+		"1.0+" + strings.Repeat("a.", n),
+		strings.Repeat(" ", n) + "1.0" + strings.Repeat(" ", n) + "x",
+		"1.0" + strings.Repeat(".post", n),
+	}
+	for _, input := range inputs {
+		start := time.Now()
+		_, _ = version.Parse(input)
+		version.IsValid(input)
+		assert.Less(t, time.Since(start), worstCaseBound, "input starting with %q", input[:10])
+	}
+}
+
+func TestNewSpecifiers_WorstCase(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping worst-case inputs in short mode")
+	}
+
+	const n = 1 << 13
+	inputs := []string{
+		strings.Repeat(">=1.0,", n) + "!",
+		strings.Repeat("||", n) + "==1.0",
+		strings.Repeat(">=1.0 ", n),
+		"==" + strings.Repeat("1.", n) + "*x",
+		strings.Repeat("=", n) + "1.0",
+		"~=1.0" + strings.Repeat(".0", n) + "-SNAPSHOT",
+	}
+	for _, input := range inputs {
+		start := time.Now()
+		_, _ = version.NewSpecifiers(input)
+		assert.Less(t, time.Since(start), worstCaseBound, "input starting with %q", input[:10])
+	}
+}
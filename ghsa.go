@@ -0,0 +1,42 @@
+package version
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// ghsaOperators are the operators of GHSA ranges, longest first.
+var ghsaOperators = []string{">=", "<=", "==", "!=", "=", ">", "<"}
+
+// ParseGHSARange parses the vulnerable_version_range of a GitHub Security Advisory, e.g. ">= 1.0, < 1.4.2" or
+// "= 1.4.1". A GHSA range compares versions in their order, so the result uses PreReleaseAllow and "> 1.0" is written
+// as ">=1.0.post0.dev0" to include the post-releases of 1.0 which ">1.0" excludes. It returns ErrInvalidSpecifier if
+// the range is malformed, and ErrInvalidVersion for a version which isn't valid.
+func ParseGHSARange(s string) (Specifiers, error) {
+	var clauses []string
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		i := slices.IndexFunc(ghsaOperators, func(op string) bool {
+			return strings.HasPrefix(c, op)
+		})
+		if i < 0 {
+			return Specifiers{}, xerrors.Errorf("GHSA range %q: clause %q without an operator: %w", s, c, ErrInvalidSpecifier)
+		}
+		op := ghsaOperators[i]
+		v, err := Parse(strings.TrimSpace(c[len(op):]))
+		if err != nil {
+			return Specifiers{}, xerrors.Errorf("GHSA range %q: %w", s, err)
+		}
+
+		switch op {
+		case "=":
+			op = string(OpEqual)
+		case ">":
+			op, v = string(OpGreaterThanEqual), v.Successor()
+		}
+		clauses = append(clauses, op+v.String())
+	}
+	return NewSpecifiers(strings.Join(clauses, ","), WithPreReleasePolicy(PreReleaseAllow))
+}
@@ -0,0 +1,45 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGHSARange(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: ">= 1.0, < 1.4.2", want: ">=1.0,<1.4.2"},
+		{input: "< 1.4.2", want: "<1.4.2"},
+		{input: "<= 2.3", want: "<=2.3"},
+		{input: "= 1.4.1", want: "==1.4.1"},
+		{input: "> 1.0, <= 2.0", want: ">=1.0.post0.dev0,<=2.0"},
+		{input: ">=1.0,<1.4.2,!=1.2", want: ">=1.0,<1.4.2,!=1.2"},
+		{input: "", wantErr: ErrInvalidSpecifier},
+		{input: ">= 1.0,", wantErr: ErrInvalidSpecifier},
+		{input: "~> 1.0", wantErr: ErrInvalidSpecifier},
+		{input: "< 1.x", wantErr: ErrInvalidVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseGHSARange(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, PreReleaseAllow, got.conf.preRelease)
+		})
+	}
+
+	ss, err := ParseGHSARange("> 1.0, < 1.4.2")
+	require.NoError(t, err)
+	for v, want := range map[string]bool{"1.0": false, "1.0.post1": true, "1.4.2rc1": true, "1.4.2": false} {
+		assert.Equal(t, want, ss.Check(MustParse(v)), v)
+	}
+}
@@ -0,0 +1,150 @@
+package version
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	// A constraint of hashicorp/go-version or aquasecurity/go-version, e.g. ">= 1.0" or "~> 1.2"
+	goVersionConstraintRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`(=>|=<|>=|<=|~>|==|!=|=|<|>|~|\^)?\s*([^\s,|<>=~^!]+)`)
+	})
+
+	// The separators of go-version constraints
+	goVersionSeparatorRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`^[\s,]*$`)
+	})
+)
+
+// ParseGoVersionConstraints parses constraints in the syntax of hashicorp/go-version and aquasecurity/go-version,
+// e.g. ">= 1.0, < 2.0", "~> 1.2" or "^1.2 || ~0.9". The pessimistic operator ~> is written as ~= with the same meaning,
+// while the tilde and caret operators are expanded like in aquasecurity/go-version, e.g. "~1.2" as ">=1.2,<1.3" and
+// "^0.2.3" as ">=0.2.3,<0.3". Like go-version, the result excludes pre-releases unless a constraint mentions one,
+// and the versions are PEP 440 versions, e.g. "1.0.0-beta1" is 1.0.0b1. It returns ErrInvalidSpecifier if the
+// constraints are malformed, and ErrInvalidVersion for a version which isn't valid.
+func ParseGoVersionConstraints(s string) (Specifiers, error) {
+	groups := strings.Split(s, "||")
+	for i, g := range groups {
+		matches := goVersionConstraintRegexp().FindAllStringSubmatchIndex(g, -1)
+		if len(matches) == 0 {
+			return Specifiers{}, xerrors.Errorf("go-version constraints %q: %w", s, ErrInvalidSpecifier)
+		}
+
+		var clauses []string
+		end := 0
+		for _, m := range matches {
+			if !goVersionSeparatorRegexp().MatchString(g[end:m[0]]) {
+				return Specifiers{}, xerrors.Errorf("go-version constraints %q: unexpected %q: %w",
+					s, g[end:m[0]], ErrInvalidSpecifier)
+			}
+			end = m[1]
+
+			op := ""
+			if m[2] >= 0 {
+				op = g[m[2]:m[3]]
+			}
+			c, err := goVersionClause(op, g[m[4]:m[5]])
+			if err != nil {
+				return Specifiers{}, xerrors.Errorf("go-version constraints %q: %w", s, err)
+			}
+			clauses = append(clauses, c...)
+		}
+		if !goVersionSeparatorRegexp().MatchString(g[end:]) {
+			return Specifiers{}, xerrors.Errorf("go-version constraints %q: unexpected %q: %w", s, g[end:], ErrInvalidSpecifier)
+		}
+		groups[i] = strings.Join(clauses, ",")
+	}
+	return NewSpecifiers(strings.Join(groups, "||"))
+}
+
+// goVersionClause returns the PEP 440 clauses of a go-version constraint.
+func goVersionClause(op, raw string) ([]string, error) {
+	v, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	spec := v.String()
+
+	switch op {
+	case "", "=", "==":
+		return []string{"==" + spec}, nil
+	case "=>":
+		return []string{">=" + spec}, nil
+	case "=<":
+		return []string{"<=" + spec}, nil
+	case "~>":
+		if len(v.release) > 1 {
+			return []string{"~=" + spec}, nil
+		}
+		return []string{">=" + spec, "<" + bumpRelease(v, 0)}, nil
+	case "~":
+		return []string{">=" + spec, "<" + bumpRelease(v, min(1, len(v.release)-1))}, nil
+	case "^":
+		// The first non-zero segment of the major, minor and patch ones is bumped
+		i := 0
+		for i < min(len(v.release), 3)-1 && v.release[i] == 0 {
+			i++
+		}
+		return []string{">=" + spec, "<" + bumpRelease(v, i)}, nil
+	}
+	return []string{op + spec}, nil
+}
+
+// bumpRelease returns the release segment of the version truncated to the i-th segment and with it incremented,
+// e.g. 1.3 for 1.2.3 and 1.
+func bumpRelease(v Version, i int) string {
+	segments := make([]string, i+1)
+	for j := range i {
+		segments[j] = strconv.FormatUint(uint64(v.release[j]), 10)
+	}
+	segments[i] = strconv.FormatUint(uint64(v.release[i])+1, 10)
+	return strings.Join(segments, ".")
+}
+
+// GoVersionConstraints returns the specifiers in the constraint syntax of hashicorp/go-version and
+// aquasecurity/go-version, e.g. ">= 1.0, < 2.0" for ">=1.0,<2.0", with ~= written as the pessimistic operator ~> and
+// "==1.2.*" as "~> 1.2.0". OR groups are joined with "||", which only aquasecurity/go-version supports. As go-version
+// orders pre-releases, post-releases and local versions differently, and excludes pre-releases like
+// PreReleaseDefault, it returns ErrUnrepresentable for other policies, for versions other than final releases
+// without an epoch, and for the other wildcard and === clauses.
+func (ss Specifiers) GoVersionConstraints() (string, error) {
+	if ss.conf.preRelease != PreReleaseDefault {
+		return "", xerrors.Errorf("go-version constraints of %q with the pre-release policy %d: %w",
+			ss, ss.conf.preRelease, ErrUnrepresentable)
+	}
+
+	groups := make([]string, len(ss.specifiers))
+	for i, group := range ss.specifiers {
+		clauses := make([]string, len(group))
+		for j, s := range group {
+			v := s.parsed
+			if s.wildcard {
+				v, _ = Parse(strings.TrimSuffix(s.version, ".*"))
+			}
+			switch {
+			case s.op == OpArbitrary || s.wildcard && s.op != OpEqual:
+				return "", xerrors.Errorf("go-version constraints of %q: %s: %w", ss, s.original, ErrUnrepresentable)
+			case v.epoch != 0 || v.IsPreRelease() || v.IsPostRelease() || v.IsDevRelease() || v.local != "":
+				return "", xerrors.Errorf("go-version constraints of %q: version %s: %w", ss, v, ErrUnrepresentable)
+			}
+
+			switch {
+			case s.wildcard:
+				clauses[j] = "~> " + v.String() + ".0"
+			case s.op == OpEqual:
+				clauses[j] = "= " + v.String()
+			case s.op == OpCompatible:
+				clauses[j] = "~> " + v.String()
+			default:
+				clauses[j] = string(s.op) + " " + v.String()
+			}
+		}
+		groups[i] = strings.Join(clauses, ", ")
+	}
+	return strings.Join(groups, " || "), nil
+}
@@ -0,0 +1,92 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoVersionConstraints(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: ">= 1.0, < 2.0", want: ">=1.0,<2.0"},
+		{input: ">=1.0 <2.0", want: ">=1.0,<2.0"},
+		{input: "1.2.3", want: "==1.2.3"},
+		{input: "= v1.2.3", want: "==1.2.3"},
+		{input: "=> 1.0, =< 2.0, != 1.5", want: ">=1.0,<=2.0,!=1.5"},
+		{input: "~> 1.2", want: "~=1.2"},
+		{input: "~> 1", want: ">=1,<2"},
+		{input: "~1.2.3", want: ">=1.2.3,<1.3"},
+		{input: "~1.2", want: ">=1.2,<1.3"},
+		{input: "~2", want: ">=2,<3"},
+		{input: "^1.2.3", want: ">=1.2.3,<2"},
+		{input: "^0.2.3", want: ">=0.2.3,<0.3"},
+		{input: "^0.0.3", want: ">=0.0.3,<0.0.4"},
+		{input: "^0.0", want: ">=0.0,<0.1"},
+		{input: "^0", want: ">=0,<1"},
+		{input: ">= 1.0.0-beta1", want: ">=1.0.0b1"},
+		{input: "^1.2 || ~0.9", want: ">=1.2,<2||>=0.9,<0.10"},
+		{input: "", wantErr: ErrInvalidSpecifier},
+		{input: ">= 1.0 ||", wantErr: ErrInvalidSpecifier},
+		{input: ">= 1.0, <", wantErr: ErrInvalidSpecifier},
+		{input: ">= 1.x", wantErr: ErrInvalidVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseGoVersionConstraints(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestSpecifiers_GoVersionConstraints(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		policy     PreReleasePolicy
+		want       string
+		wantErr    error
+	}{
+		{specifiers: ">=1.0,<2.0", want: ">= 1.0, < 2.0"},
+		{specifiers: "==1.2.3", want: "= 1.2.3"},
+		{specifiers: "1.2.3", want: "= 1.2.3"},
+		{specifiers: "~=1.2", want: "~> 1.2"},
+		{specifiers: "==1.2.*", want: "~> 1.2.0"},
+		{specifiers: "<1.0||>=2.0,!=2.1", want: "< 1.0 || >= 2.0, != 2.1"},
+		{specifiers: "!=1.2.*", wantErr: ErrUnrepresentable},
+		{specifiers: "===1.0", wantErr: ErrUnrepresentable},
+		{specifiers: ">=1.0rc1", wantErr: ErrUnrepresentable},
+		{specifiers: ">=1.0.post1", wantErr: ErrUnrepresentable},
+		{specifiers: "==1.0+local", wantErr: ErrUnrepresentable},
+		{specifiers: ">=1!1.0", wantErr: ErrUnrepresentable},
+		{specifiers: ">=1.0", policy: PreReleaseAllow, wantErr: ErrUnrepresentable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers, WithPreReleasePolicy(tt.policy))
+			require.NoError(t, err)
+
+			got, err := ss.GoVersionConstraints()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			parsed, err := ParseGoVersionConstraints(got)
+			require.NoError(t, err)
+			for _, v := range []string{"0.9", "1.0", "1.2.2", "1.2.3", "1.2.9", "1.3", "1.5", "2.0", "2.1", "2.2"} {
+				assert.Equal(t, ss.Check(MustParse(v)), parsed.Check(MustParse(v)), "%s as %s: %s", ss, parsed, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	// The attributes and the text of the anchors of a simple index page
+	anchorRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`(?is)<a(\s[^>]*)?>(.*?)</a\s*>`)
+	})
+
+	// An attribute of an HTML tag with an optional double-quoted, single-quoted or unquoted value
+	attributeRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`(?s)([A-Za-z_:][A-Za-z0-9_:.-]*)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+)))?`)
+	})
+)
+
+// sdistExtensions are the extensions of the source distributions found on package indexes.
+var sdistExtensions = []string{".tar.gz", ".tar.bz2", ".tar.xz", ".tgz", ".tar", ".zip"}
+
+// IndexFile is a distribution file of a project listed by a simple repository API (PEP 503 and PEP 691).
+type IndexFile struct {
+	// Filename is the name of the file, e.g. "requests-2.31.0-py3-none-any.whl".
+	Filename string
+	// URL is the location of the file as written on the page, possibly relative to the page and with a hash fragment.
+	URL string
+	// Version is the version of the distribution in the filename.
+	Version Version
+	// Yanked is true if the file is yanked (PEP 592), with the optional reason in YankedReason.
+	Yanked       bool
+	YankedReason string
+	// RequiresPython are the Python versions supported by the file. The zero Specifiers allows every version.
+	RequiresPython Specifiers
+}
+
+// ParseSimpleIndex parses the page of a project of a simple repository API, either in HTML (PEP 503) or in JSON
+// (PEP 691), and returns its wheels and source distributions in the order of the page. The versions of source
+// distributions are found with the project name, e.g. "2.31.0" in "requests-2.31.0.tar.gz" for "requests".
+// Like pip, it skips the files of other kinds or with versions which aren't valid, and ignores requires-python
+// specifiers which aren't valid. It returns an error only if a JSON page is malformed.
+func ParseSimpleIndex(project string, data []byte, opts ...SpecifierOption) ([]IndexFile, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return parseSimpleIndexJSON(project, trimmed, opts)
+	}
+
+	var files []IndexFile
+	for _, m := range anchorRegexp().FindAllStringSubmatch(string(data), -1) {
+		attrs := parseAttributes(m[1])
+		href, ok := attrs["href"]
+		if !ok {
+			continue
+		}
+		f := IndexFile{Filename: strings.TrimSpace(html.UnescapeString(m[2])), URL: href}
+		if f.Filename == "" {
+			f.Filename = indexFilename(href)
+		}
+		f.YankedReason, f.Yanked = attrs["data-yanked"]
+		if f, ok = newIndexFile(project, f, attrs["data-requires-python"], opts); ok {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+func parseSimpleIndexJSON(project string, data []byte, opts []SpecifierOption) ([]IndexFile, error) {
+	var page struct {
+		Files []struct {
+			Filename       string `json:"filename"`
+			URL            string `json:"url"`
+			RequiresPython string `json:"requires-python"`
+			Yanked         any    `json:"yanked"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, xerrors.Errorf("simple index of %s: %w", project, err)
+	}
+
+	var files []IndexFile
+	for _, e := range page.Files {
+		f := IndexFile{Filename: e.Filename, URL: e.URL}
+		switch yanked := e.Yanked.(type) {
+		case bool:
+			f.Yanked = yanked
+		case string:
+			f.Yanked, f.YankedReason = true, yanked
+		}
+		if f, ok := newIndexFile(project, f, e.RequiresPython, opts); ok {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// newIndexFile fills the version and the requires-python specifiers of the file,
+// and returns false if the file isn't a distribution of the project with a valid version.
+func newIndexFile(project string, f IndexFile, requiresPython string, opts []SpecifierOption) (IndexFile, bool) {
+	var ok bool
+	if f.Version, ok = indexFileVersion(project, f.Filename); !ok {
+		return IndexFile{}, false
+	}
+	if strings.TrimSpace(requiresPython) != "" {
+		if ss, err := NewSpecifiers(requiresPython, opts...); err == nil {
+			f.RequiresPython = ss
+		}
+	}
+	return f, true
+}
+
+// indexFileVersion returns the version in the filename of a wheel or a source distribution of the project.
+func indexFileVersion(project, filename string) (Version, bool) {
+	if strings.HasSuffix(filename, ".whl") {
+		w, err := ParseWheelFilename(filename)
+		return w.Version, err == nil
+	}
+
+	for _, ext := range sdistExtensions {
+		base, ok := strings.CutSuffix(filename, ext)
+		if !ok {
+			continue
+		}
+		// The project name may contain '-', so the name is found by comparing the normalized names
+		name := Requirement{Name: project}.normalizedName()
+		for i := range len(base) {
+			if base[i] != '-' || (Requirement{Name: base[:i]}).normalizedName() != name {
+				continue
+			}
+			v, err := Parse(base[i+1:])
+			return v, err == nil
+		}
+		return Version{}, false
+	}
+	return Version{}, false
+}
+
+// indexFilename returns the filename in the path of the URL.
+func indexFilename(url string) string {
+	url, _, _ = strings.Cut(url, "#")
+	url, _, _ = strings.Cut(url, "?")
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
+// parseAttributes returns the unescaped attributes of an HTML tag by their lowercase names.
+func parseAttributes(s string) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range attributeRegexp().FindAllStringSubmatch(s, -1) {
+		name := strings.ToLower(m[1])
+		if _, ok := attrs[name]; !ok {
+			attrs[name] = html.UnescapeString(m[2] + m[3] + m[4])
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,123 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSimpleIndex(t *testing.T) {
+	type file struct {
+		Filename, URL, Version string
+		Yanked                 bool
+		YankedReason           string
+		RequiresPython         string
+	}
+	tests := []struct {
+		name    string
+		project string
+		page    string
+		want    []file
+		wantErr bool
+	}{
+		{
+			name:    "html",
+			project: "Zope.Interface",
+			page: `<!DOCTYPE html>
+<html>
+  <body>
+    <h1>Links for zope-interface</h1>
+    <a href="../../packages/zope.interface-5.0.tar.gz#sha256=abc">zope.interface-5.0.tar.gz</a><br/>
+    <a href="../../packages/zope_interface-6.0-cp311-cp311-win_amd64.whl#sha256=def" data-requires-python="&gt;=3.7">zope_interface-6.0-cp311-cp311-win_amd64.whl</a>
+    <A HREF='zope.interface-6.1.zip' data-yanked>zope.interface-6.1.zip</A>
+    <a href="zope.interface-6.2.tar.gz" data-yanked="broken &amp; removed" data-requires-python="&gt;=3.8,&lt;4"></a>
+    <a href="zope.interface-6.3.tar.gz" data-requires-python="&gt;=three">zope.interface-6.3.tar.gz</a>
+    <a href="zope.interface-latest.tar.gz">zope.interface-latest.tar.gz</a>
+    <a href="zope.interface-1.0.win32.exe">zope.interface-1.0.win32.exe</a>
+    <a href="zope.interface.extra-1.0.tar.gz">zope.interface.extra-1.0.tar.gz</a>
+    <a name="anchor">no link</a>
+  </body>
+</html>`,
+			want: []file{
+				{Filename: "zope.interface-5.0.tar.gz", URL: "../../packages/zope.interface-5.0.tar.gz#sha256=abc", Version: "5.0"},
+				{
+					Filename:       "zope_interface-6.0-cp311-cp311-win_amd64.whl",
+					URL:            "../../packages/zope_interface-6.0-cp311-cp311-win_amd64.whl#sha256=def",
+					Version:        "6.0",
+					RequiresPython: ">=3.7",
+				},
+				{Filename: "zope.interface-6.1.zip", URL: "zope.interface-6.1.zip", Version: "6.1", Yanked: true},
+				{
+					Filename:       "zope.interface-6.2.tar.gz",
+					URL:            "zope.interface-6.2.tar.gz",
+					Version:        "6.2",
+					Yanked:         true,
+					YankedReason:   "broken & removed",
+					RequiresPython: ">=3.8,<4",
+				},
+				{Filename: "zope.interface-6.3.tar.gz", URL: "zope.interface-6.3.tar.gz", Version: "6.3"},
+			},
+		},
+		{
+			name:    "json",
+			project: "requests",
+			page: `{
+  "meta": {"api-version": "1.0"},
+  "name": "requests",
+  "files": [
+    {"filename": "requests-2.30.0.tar.gz", "url": "https://files.example.com/requests-2.30.0.tar.gz", "hashes": {}},
+    {"filename": "requests-2.31.0-py3-none-any.whl", "url": "https://files.example.com/requests-2.31.0-py3-none-any.whl",
+     "hashes": {}, "requires-python": ">=3.7", "yanked": false},
+    {"filename": "requests-2.32.0.tar.gz", "url": "https://files.example.com/requests-2.32.0.tar.gz", "hashes": {},
+     "yanked": true},
+    {"filename": "requests-2.32.1.tar.gz", "url": "https://files.example.com/requests-2.32.1.tar.gz", "hashes": {},
+     "yanked": "conflicts with urllib3"},
+    {"filename": "requests-2.32.1.egg", "url": "https://files.example.com/requests-2.32.1.egg", "hashes": {}}
+  ]
+}`,
+			want: []file{
+				{Filename: "requests-2.30.0.tar.gz", URL: "https://files.example.com/requests-2.30.0.tar.gz", Version: "2.30.0"},
+				{
+					Filename:       "requests-2.31.0-py3-none-any.whl",
+					URL:            "https://files.example.com/requests-2.31.0-py3-none-any.whl",
+					Version:        "2.31.0",
+					RequiresPython: ">=3.7",
+				},
+				{Filename: "requests-2.32.0.tar.gz", URL: "https://files.example.com/requests-2.32.0.tar.gz", Version: "2.32.0", Yanked: true},
+				{
+					Filename:     "requests-2.32.1.tar.gz",
+					URL:          "https://files.example.com/requests-2.32.1.tar.gz",
+					Version:      "2.32.1",
+					Yanked:       true,
+					YankedReason: "conflicts with urllib3",
+				},
+			},
+		},
+		{name: "empty", project: "requests", page: "<html><body></body></html>"},
+		{name: "malformed json", project: "requests", page: `{"files": [`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSimpleIndex(tt.project, []byte(tt.page))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var files []file
+			for _, f := range got {
+				files = append(files, file{
+					Filename:       f.Filename,
+					URL:            f.URL,
+					Version:        f.Version.String(),
+					Yanked:         f.Yanked,
+					YankedReason:   f.YankedReason,
+					RequiresPython: f.RequiresPython.String(),
+				})
+			}
+			assert.Equal(t, tt.want, files)
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package version
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Bound is an endpoint of an Interval.
+type Bound struct {
+	// Version is the endpoint. It is ignored if Unbounded. NegInf and Inf bound the interval like Unbounded
+	// or make it empty, e.g. an upper bound of NegInf.
+	Version Version
+	// Inclusive reports whether Version itself lies in the interval.
+	Inclusive bool
+	// AfterRelease moves the endpoint above every version with the epoch and release segment of Version,
+	// including its post-releases and local versions, e.g. the lower bound of ">2.0" excludes 2.0.post1.
+	// Inclusive is ignored if it is set.
+	AfterRelease bool
+	// Unbounded reports whether the interval extends infinitely past the bound.
+	Unbounded bool
+}
+
+// Interval is a contiguous range of versions in the order of versions.
+type Interval struct {
+	Lower, Upper Bound
+}
+
+// Contains reports whether the version lies in the interval.
+func (i Interval) Contains(v Version) bool {
+	return i.span().contains(v)
+}
+
+// String returns the interval in the mathematical notation, e.g. "[1.0, 2.0)", "(-inf, 2.0]" or "(2.0.post*, inf)",
+// where "2.0.post*" is a bound above every version of the release 2.0.
+func (i Interval) String() string {
+	var sb strings.Builder
+	switch {
+	case i.Lower.Unbounded:
+		sb.WriteString("(-inf")
+	case i.Lower.AfterRelease:
+		sb.WriteString("(" + i.Lower.releaseString())
+	case i.Lower.Inclusive:
+		sb.WriteString("[" + i.Lower.Version.String())
+	default:
+		sb.WriteString("(" + i.Lower.Version.String())
+	}
+	sb.WriteString(", ")
+	switch {
+	case i.Upper.Unbounded:
+		sb.WriteString("inf)")
+	case i.Upper.AfterRelease:
+		sb.WriteString(i.Upper.releaseString() + "]")
+	case i.Upper.Inclusive:
+		sb.WriteString(i.Upper.Version.String() + "]")
+	default:
+		sb.WriteString(i.Upper.Version.String() + ")")
+	}
+	return sb.String()
+}
+
+func (b Bound) releaseString() string {
+	return finalRelease(b.Version).String() + ".post*"
+}
+
+// span returns the span of the versions in the interval.
+func (i Interval) span() cutSpan {
+	lo := cut{kind: belowAll}
+	switch {
+	case i.Lower.Unbounded:
+	case i.Lower.Version.infinity != 0:
+		lo = infinityCut(i.Lower.Version)
+	case i.Lower.AfterRelease:
+		lo = aboveRelease(i.Lower.Version)
+	case i.Lower.Inclusive:
+		lo = below(i.Lower.Version)
+	default:
+		lo = above(i.Lower.Version)
+	}
+
+	hi := cut{kind: aboveAll}
+	switch {
+	case i.Upper.Unbounded:
+	case i.Upper.Version.infinity != 0:
+		hi = infinityCut(i.Upper.Version)
+	case i.Upper.AfterRelease:
+		hi = aboveRelease(i.Upper.Version)
+	case i.Upper.Inclusive:
+		hi = above(i.Upper.Version)
+	default:
+		hi = below(i.Upper.Version)
+	}
+	return cutSpan{lo: lo, hi: hi}
+}
+
+// infinityCut returns the cut below every version for NegInf and above every version for Inf.
+func infinityCut(v Version) cut {
+	if v.infinity < 0 {
+		return cut{kind: belowAll}
+	}
+	return cut{kind: aboveAll}
+}
+
+// lowest reports whether no version is below the cut, as 0.dev0 is the lowest version.
+func (c cut) lowest() bool {
+	return c.kind == belowAll || c.kind == beforeVersion && c.v.Equal(MustParse("0.dev0"))
+}
+
+// bound returns the cut as an endpoint of an interval above it if lower, or below it otherwise.
+func (c cut) bound(lower bool) Bound {
+	switch c.kind {
+	case belowAll, aboveAll:
+		return Bound{Unbounded: true}
+	case afterRelease:
+		return Bound{Version: finalRelease(c.v), AfterRelease: true}
+	case afterLocals:
+		// There are no versions in between the local versions of a version and its successor
+		return Bound{Version: c.v.Successor(), Inclusive: lower}
+	case afterVersion:
+		return Bound{Version: c.v, Inclusive: !lower}
+	}
+	return Bound{Version: c.v, Inclusive: lower}
+}
+
+// Ranges returns the versions satisfying the specifiers as sorted, disjoint intervals,
+// e.g. [(-inf, 1.0), [1.0.post0.dev0, 2.0.dev0)] for "<2.0,!=1.0". The approximations of IsEmpty apply, and
+// the intervals of ">1.0.post0" include the local versions of the later post-releases like 1.0.post1+local,
+// which it rejects.
+func (ss Specifiers) Ranges() []Interval {
+	spans := ss.spans()
+	if len(spans) == 0 {
+		return nil
+	}
+	intervals := make([]Interval, len(spans))
+	for i, s := range spans {
+		intervals[i] = s.interval()
+	}
+	return intervals
+}
+
+// interval returns the span as an Interval.
+func (s cutSpan) interval() Interval {
+	return Interval{Lower: s.lo.bound(true), Upper: s.hi.bound(false)}
+}
+
+// Boundary is a point in the order of versions where a clause of Specifiers starts or stops matching.
+type Boundary struct {
+	// Bound is the point as the lower bound of the versions above it, e.g. 2.0.dev0 inclusive for "<2.0",
+	// which stops matching at 2.0.dev0.
+	Bound Bound
+	// Clauses are the clauses changing at the point in the order of the specifiers.
+	Clauses []Specifier
+}
+
+// Boundaries returns the points where the clauses start or stop matching in the order of versions,
+// e.g. 1.0, 1.5, 1.5.post0.dev0 and 2.0.dev0 for ">=1.0,<2.0,!=1.5". Versions in between two adjacent points
+// are matched alike by every clause. The approximations of IsEmpty apply.
+func (ss Specifiers) Boundaries() []Boundary {
+	type point struct {
+		cut    cut
+		clause Specifier
+	}
+	var points []point
+	for _, and := range ss.specifiers {
+		for _, s := range and {
+			clause := Specifier{Operator: s.op, Version: s.version}
+			for _, span := range s.spans(ss.conf.preRelease == PreReleaseAllow) {
+				for _, c := range []cut{span.lo, span.hi} {
+					if c.extreme() == 0 {
+						points = append(points, point{cut: c, clause: clause})
+					}
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(points, func(a, b point) int {
+		return compareCuts(a.cut, b.cut)
+	})
+
+	var boundaries []Boundary
+	for i, p := range points {
+		if i == 0 || compareCuts(points[i-1].cut, p.cut) != 0 {
+			boundaries = append(boundaries, Boundary{Bound: p.cut.bound(true)})
+		}
+		if last := &boundaries[len(boundaries)-1]; !slices.Contains(last.Clauses, p.clause) {
+			last.Clauses = append(last.Clauses, p.clause)
+		}
+	}
+	return boundaries
+}
+
+// FromRanges returns the specifiers satisfied by the versions in the intervals, written with >=, <, <=, >, !=, ==
+// and wildcards as tightly as possible, e.g. ">=1.0,<2.0,!=1.5.*" for [1.0, 1.5.dev0) and [1.6.dev0, 2.0.dev0).
+// As PEP 440 specifiers can't tell the local versions of a version apart from it except with ==, they are treated
+// like the public version of a bound, e.g. [1.0, 2.0] includes 2.0+local. Since < excludes the pre-releases of a final
+// or post-release, the result uses PreReleaseAllow if an interval excludes such a version and includes its
+// pre-releases, e.g. [1.0, 2.0). It returns ErrUnrepresentable if the versions can't be written as specifiers,
+// e.g. for an empty interval or one up to every post-release of 2.0.
+func FromRanges(intervals []Interval) (Specifiers, error) {
+	spans := make([]cutSpan, 0, len(intervals))
+	for _, i := range intervals {
+		spans = append(spans, i.publicSpan())
+	}
+	ss, err := fromSpans(normalizeSpans(spans))
+	if err != nil {
+		return Specifiers{}, xerrors.Errorf("%v: %w", intervals, err)
+	}
+	return ss, nil
+}
+
+// fromSpans returns the specifiers satisfied by the versions in the sorted, disjoint spans.
+func fromSpans(merged cutSpans) (Specifiers, error) {
+	if len(merged) == 0 {
+		return Specifiers{}, xerrors.Errorf("no versions: %w", ErrUnrepresentable)
+	}
+
+	// Gaps which can be written as != are excluded from a group spanning both sides
+	type group struct {
+		span  cutSpan
+		holes []string
+	}
+	var groups []group
+	for i := 0; i < len(merged); {
+		g := group{span: merged[i]}
+		for i++; i < len(merged); i++ {
+			hole, ok := emitter{}.clause(OpNotEqual, cutSpan{lo: g.span.hi, hi: merged[i].lo})
+			if !ok {
+				break
+			}
+			g.span.hi, g.holes = merged[i].hi, append(g.holes, hole)
+		}
+		groups = append(groups, g)
+	}
+
+	policy := PreReleaseDefault
+	for _, g := range groups {
+		if hi := g.span.hi; hi.kind == beforeVersion && hi.v.local == "" && !hi.v.IsPreRelease() {
+			policy = PreReleaseAllow
+		}
+	}
+	e := emitter{allowPreRelease: policy == PreReleaseAllow}
+
+	clauses := make([]string, len(groups))
+	for i, g := range groups {
+		var err error
+		if clauses[i], err = e.group(g.span, g.holes); err != nil {
+			return Specifiers{}, err
+		}
+	}
+
+	return NewSpecifiers(strings.Join(clauses, "||"), WithPreReleasePolicy(policy))
+}
+
+// publicSpan returns the span of the interval with the local versions of public bounds included in the bounds.
+func (i Interval) publicSpan() cutSpan {
+	s := i.span()
+	if s.lo.kind == afterVersion && s.lo.v.local == "" {
+		s.lo = aboveLocals(s.lo.v)
+	}
+	if s.hi.kind == afterVersion && s.hi.v.local == "" {
+		s.hi = aboveLocals(s.hi.v)
+	}
+	return s
+}
+
+// emitter writes spans as clauses.
+type emitter struct {
+	allowPreRelease bool
+}
+
+// group returns the clauses satisfied by the versions in the span except the holes.
+func (e emitter) group(s cutSpan, holes []string) (string, error) {
+	if len(holes) == 0 {
+		if clause, ok := e.clause(OpEqual, s); ok {
+			return clause, nil
+		} else if clause, ok := e.compatible(s); ok {
+			return clause, nil
+		}
+	}
+
+	var clauses []string
+	switch s.lo.kind {
+	case belowAll:
+	case beforeVersion:
+		if s.lo.v.local != "" {
+			return "", xerrors.Errorf("local version %s as a lower bound: %w", s.lo.v, ErrUnrepresentable)
+		}
+		clauses = append(clauses, ">="+s.lo.v.String())
+	case afterLocals:
+		clauses = append(clauses, ">="+s.lo.v.Successor().String())
+	case afterRelease:
+		clauses = append(clauses, ">"+finalRelease(s.lo.v).String())
+	default:
+		return "", xerrors.Errorf("local version %s as a lower bound: %w", s.lo.v, ErrUnrepresentable)
+	}
+
+	switch s.hi.kind {
+	case aboveAll:
+	case beforeVersion:
+		if s.hi.v.local != "" {
+			return "", xerrors.Errorf("local version %s as an upper bound: %w", s.hi.v, ErrUnrepresentable)
+		}
+		clauses = append(clauses, "<"+e.exclusiveUpper(s.hi.v))
+	case afterLocals:
+		clauses = append(clauses, "<="+s.hi.v.String())
+	case afterRelease:
+		return "", xerrors.Errorf("every version of %s as an upper bound: %w", finalRelease(s.hi.v), ErrUnrepresentable)
+	default:
+		return "", xerrors.Errorf("local version %s as an upper bound: %w", s.hi.v, ErrUnrepresentable)
+	}
+
+	clauses = append(clauses, holes...)
+	if len(clauses) == 0 {
+		// Every version, as 0.dev0 is the lowest one
+		clauses = append(clauses, ">=0.dev0")
+	}
+	return strings.Join(clauses, ","), nil
+}
+
+// exclusiveUpper returns the version to write after < for the versions below v.
+func (e emitter) exclusiveUpper(v Version) string {
+	// <2.0 excludes 2.0rc1 and 2.0.dev0 unless pre-releases are included
+	if f := finalRelease(v); !e.allowPreRelease && withDev0(f).Equal(v) {
+		return f.String()
+	}
+	return v.String()
+}
+
+// clause returns a clause with the operator == or != and a version or a wildcard,
+// whose matching (==) or excluded (!=) versions are the span.
+func (e emitter) clause(op Operator, s cutSpan) (string, bool) {
+	var lo Version
+	switch s.lo.kind {
+	case beforeVersion:
+		lo = s.lo.v
+	case afterLocals:
+		lo = s.lo.v.Successor()
+	default:
+		return "", false
+	}
+
+	candidates := []string{lo.String()}
+	if f := finalRelease(lo); withDev0(f).Equal(lo) && lo.epoch == 0 && lo.pre.isNull() && lo.post.isNull() {
+		for n := len(f.release); n > 0; n-- {
+			candidates = append(candidates, f.Truncate(n).String()+".*")
+		}
+	}
+	for _, c := range candidates {
+		if e.matches(string(OpEqual)+c, s) {
+			return string(op) + c, true
+		}
+	}
+	return "", false
+}
+
+// compatible returns a clause with the operator ~= matching the versions in the span.
+func (e emitter) compatible(s cutSpan) (string, bool) {
+	if s.lo.kind != beforeVersion && s.lo.kind != afterLocals {
+		return "", false
+	}
+	v := s.lo.v
+	if s.lo.kind == afterLocals {
+		v = v.Successor()
+	}
+	clause := string(OpCompatible) + v.String()
+	return clause, e.matches(clause, s)
+}
+
+// matches reports whether the clause matches exactly the versions in the span.
+func (e emitter) matches(clause string, s cutSpan) bool {
+	spec, err := newSpecifier(clause)
+	return err == nil && conf{}.exact(spec) && spec.spans(e.allowPreRelease).equal(cutSpans{s})
+}
@@ -0,0 +1,281 @@
+package version
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecifiers_Ranges(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		opts       []SpecifierOption
+		want       []string
+	}{
+		{specifiers: ">=1.0,<2.0", want: []string{"[1.0, 2.0.dev0)"}},
+		{specifiers: ">=1.0,<2.0", opts: []SpecifierOption{WithPreRelease(true)}, want: []string{"[1.0, 2.0)"}},
+		{specifiers: "<2.0,!=1.0", want: []string{"(-inf, 1.0)", "[1.0.post0.dev0, 2.0.dev0)"}},
+		{specifiers: ">2.0", want: []string{"(2.0.post*, inf)"}},
+		// 1.0.post1+local is included though >1.0.post0 rejects it
+		{specifiers: ">1.0.post0", want: []string{"[1.0.post1.dev0, inf)"}},
+		{specifiers: "<=2.0 || >3.0", want: []string{"(-inf, 2.0.post0.dev0)", "(3.0.post*, inf)"}},
+		{specifiers: "==1.*", want: []string{"[1.dev0, 2.dev0)"}},
+		{specifiers: "~=1.4.5", want: []string{"[1.4.5, 1.5.dev0)"}},
+		{specifiers: "==1.0+local", want: []string{"[1.0+local, 1.0+local]"}},
+		{specifiers: "==1.0", want: []string{"[1.0, 1.0.post0.dev0)"}},
+		{specifiers: "!=1.0", want: []string{"(-inf, 1.0)", "[1.0.post0.dev0, inf)"}},
+		{specifiers: ">=1.0,<2.0 || >=1.5,<3.0", want: []string{"[1.0, 3.0.dev0)"}},
+		{specifiers: ">2.0,<1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers, tt.opts...)
+			require.NoError(t, err)
+
+			var got []string
+			for _, i := range ss.Ranges() {
+				got = append(got, i.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSpecifiers_Ranges_Contains(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	for _, s := range rangeSpecifiers {
+		ss, err := NewSpecifiers(s)
+		require.NoError(t, err)
+		ranges := ss.Ranges()
+		for _, v := range versions {
+			var got bool
+			for _, i := range ranges {
+				got = got || i.Contains(v)
+			}
+			if widenedSpecifiers[s] {
+				assert.True(t, !ss.Check(v) || got, "%s %s", s, v)
+			} else {
+				assert.Equal(t, ss.Check(v), got, "%s %s", s, v)
+			}
+		}
+	}
+}
+
+func TestInterval_Contains(t *testing.T) {
+	tests := []struct {
+		interval Interval
+		version  string
+		want     bool
+	}{
+		{Interval{Lower: Bound{Version: MustParse("1.0"), Inclusive: true}, Upper: Bound{Unbounded: true}}, "1.0", true},
+		{Interval{Lower: Bound{Version: MustParse("1.0")}, Upper: Bound{Unbounded: true}}, "1.0", false},
+		{Interval{Lower: Bound{Version: MustParse("1.0")}, Upper: Bound{Unbounded: true}}, "1.0+local", true},
+		{Interval{Lower: Bound{Version: MustParse("1.0"), AfterRelease: true}, Upper: Bound{Unbounded: true}}, "1.0.post1", false},
+		{Interval{Lower: Bound{Version: MustParse("1.0"), AfterRelease: true}, Upper: Bound{Unbounded: true}}, "1.0.0.1", true},
+		{Interval{Lower: Bound{Unbounded: true}, Upper: Bound{Version: MustParse("2.0")}}, "2.0rc1", true},
+		{Interval{Lower: Bound{Unbounded: true}, Upper: Bound{Version: MustParse("2.0"), Inclusive: true}}, "2.0+local", false},
+		{Interval{Lower: Bound{Unbounded: true}, Upper: Bound{Version: MustParse("2.0rc1"), AfterRelease: true}}, "2.0.post1", true},
+		{Interval{Lower: Bound{Version: NegInf}, Upper: Bound{Version: MustParse("2.0")}}, "0.dev0", true},
+		{Interval{Lower: Bound{Version: MustParse("1.0"), Inclusive: true}, Upper: Bound{Version: Inf}}, "99.0+local", true},
+		{Interval{Lower: Bound{Version: Inf}, Upper: Bound{Unbounded: true}}, "99.0", false},
+		{Interval{Lower: Bound{Unbounded: true}, Upper: Bound{Version: NegInf, Inclusive: true}}, "0.dev0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.interval.String()+" "+tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.interval.Contains(MustParse(tt.version)))
+		})
+	}
+}
+
+func TestFromRanges(t *testing.T) {
+	bound := func(s string, inclusive bool) Bound {
+		return Bound{Version: MustParse(s), Inclusive: inclusive}
+	}
+	tests := []struct {
+		name      string
+		intervals []Interval
+		want      string
+		policy    PreReleasePolicy
+		wantErr   bool
+	}{
+		{
+			name:      "half-open",
+			intervals: []Interval{{Lower: bound("1.0", true), Upper: bound("3.0.dev0", false)}},
+			want:      ">=1.0,<3.0",
+		},
+		{
+			name:      "pre-releases of the upper bound",
+			intervals: []Interval{{Lower: bound("1.0", true), Upper: bound("3.0", false)}},
+			want:      ">=1.0,<3.0",
+			policy:    PreReleaseAllow,
+		},
+		{
+			name:      "closed",
+			intervals: []Interval{{Lower: bound("1.0", false), Upper: bound("2.0", true)}},
+			want:      ">=1.0.post0.dev0,<=2.0",
+		},
+		{
+			name: "hole",
+			intervals: []Interval{
+				{Lower: Bound{Unbounded: true}, Upper: bound("1.0", false)},
+				{Lower: bound("1.0", false), Upper: bound("2.0rc1", false)},
+			},
+			want: "<2.0rc1,!=1.0",
+		},
+		{
+			name: "wildcard hole",
+			intervals: []Interval{
+				{Lower: bound("1.0", true), Upper: bound("1.5.dev0", false)},
+				{Lower: bound("1.6.dev0", true), Upper: bound("2.0.dev0", false)},
+			},
+			want: ">=1.0,<2.0,!=1.5.*",
+		},
+		{
+			name:      "wildcard",
+			intervals: []Interval{{Lower: bound("1.dev0", true), Upper: bound("2.dev0", false)}},
+			want:      "==1.*",
+		},
+		{
+			name:      "compatible",
+			intervals: []Interval{{Lower: bound("1.4.5", true), Upper: bound("1.5.dev0", false)}},
+			want:      "~=1.4.5",
+		},
+		{
+			name:      "single version",
+			intervals: []Interval{{Lower: bound("1.0", true), Upper: bound("1.0", true)}},
+			want:      "==1.0",
+		},
+		{
+			name:      "local version",
+			intervals: []Interval{{Lower: bound("1.0+local", true), Upper: bound("1.0+local", true)}},
+			want:      "==1.0+local",
+		},
+		{
+			name: "disjoint",
+			intervals: []Interval{
+				{Lower: Bound{Unbounded: true}, Upper: bound("1.0", true)},
+				{Lower: Bound{Version: MustParse("2.0"), AfterRelease: true}, Upper: Bound{Unbounded: true}},
+			},
+			want: "<=1.0||>2.0",
+		},
+		{
+			name:      "sentinels",
+			intervals: []Interval{{Lower: Bound{Version: NegInf}, Upper: bound("2.0.dev0", false)}, {Lower: bound("3.0", true), Upper: Bound{Version: Inf}}},
+			want:      "<2.0||>=3.0",
+		},
+		{
+			name:      "every version",
+			intervals: []Interval{{Lower: Bound{Unbounded: true}, Upper: Bound{Unbounded: true}}},
+			want:      ">=0.dev0",
+		},
+		{
+			name:      "empty",
+			intervals: []Interval{{Lower: bound("2.0", true), Upper: bound("1.0", false)}},
+			wantErr:   true,
+		},
+		{
+			name:      "after release",
+			intervals: []Interval{{Lower: Bound{Unbounded: true}, Upper: Bound{Version: MustParse("2.0"), AfterRelease: true}}},
+			wantErr:   true,
+		},
+		{
+			name:      "local bound",
+			intervals: []Interval{{Lower: bound("1.0+local", true), Upper: Bound{Unbounded: true}}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromRanges(tt.intervals)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnrepresentable)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.policy, got.conf.preRelease)
+		})
+	}
+}
+
+func TestFromRanges_RoundTrip(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	for _, s := range rangeSpecifiers {
+		ss, err := NewSpecifiers(s)
+		require.NoError(t, err)
+		if slices.ContainsFunc(slices.Concat(ss.specifiers...), ss.conf.inexact) || ss.IsEmpty() {
+			continue
+		}
+		got, err := FromRanges(ss.Ranges())
+		if errors.Is(err, ErrUnrepresentable) {
+			continue
+		}
+		require.NoError(t, err, s)
+		for _, v := range versions {
+			assert.Equal(t, ss.Check(v), got.Check(v), "%s %s %s", s, got, v)
+		}
+	}
+}
+
+func TestSpecifiers_Boundaries(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		want       []string
+	}{
+		{specifiers: ">=1.0,<2.0,!=1.5", want: []string{"[1.0 >=1.0", "[1.5 !=1.5", "[1.5.post0.dev0 !=1.5", "[2.0.dev0 <2.0"}},
+		{specifiers: ">2.0", want: []string{"(2.0.post* >2.0"}},
+		{specifiers: "==1.0+local", want: []string{"[1.0+local ==1.0+local", "(1.0+local ==1.0+local"}},
+		{specifiers: "==1.0 || !=1.0", want: []string{"[1.0 ==1.0 !=1.0", "[1.0.post0.dev0 ==1.0 !=1.0"}},
+		{specifiers: ">=1.0,<2.0 || >=1.0,<3.0", want: []string{"[1.0 >=1.0", "[2.0.dev0 <2.0", "[3.0.dev0 <3.0"}},
+		{specifiers: "==1.*", want: []string{"[1.dev0 ==1.*", "[2.dev0 ==1.*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers)
+			require.NoError(t, err)
+
+			var got []string
+			for _, b := range ss.Boundaries() {
+				s := strings.TrimSuffix(Interval{Lower: b.Bound, Upper: Bound{Unbounded: true}}.String(), ", inf)")
+				for _, c := range b.Clauses {
+					s += " " + c.String()
+				}
+				got = append(got, s)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSpecifiers_Boundaries_Between(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+	slices.SortFunc(versions, Version.Compare)
+
+	for _, s := range rangeSpecifiers {
+		ss, err := NewSpecifiers(s)
+		require.NoError(t, err)
+		if widenedSpecifiers[s] {
+			continue
+		}
+		boundaries := ss.Boundaries()
+		for i := 1; i < len(versions); i++ {
+			prev, v := versions[i-1], versions[i]
+			crossed := slices.ContainsFunc(boundaries, func(b Boundary) bool {
+				above := Interval{Lower: b.Bound, Upper: Bound{Unbounded: true}}
+				return !above.Contains(prev) && above.Contains(v)
+			})
+			if !crossed {
+				assert.Equal(t, ss.Check(prev), ss.Check(v), "%s %s %s", s, prev, v)
+			}
+		}
+	}
+}
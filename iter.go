@@ -0,0 +1,45 @@
+//go:build go1.23
+
+package version
+
+import (
+	"iter"
+)
+
+// All returns an iterator over the versions in the order of the collection.
+func (c Collection) All() iter.Seq[Version] {
+	return func(yield func(Version) bool) {
+		for _, v := range c {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the versions in the set in ascending order.
+func (s VersionSet) All() iter.Seq[Version] {
+	return s.Versions().All()
+}
+
+// Matching returns an iterator over the candidates satisfying the specifiers, in their order.
+func (ss Specifiers) Matching(candidates iter.Seq[Version]) iter.Seq[Version] {
+	return func(yield func(Version) bool) {
+		for v := range candidates {
+			if ss.Check(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ParseSeq returns an iterator parsing the versions like Parse, yielding each version with its error.
+func ParseSeq(vs iter.Seq[string]) iter.Seq2[Version, error] {
+	return func(yield func(Version, error) bool) {
+		for s := range vs {
+			if !yield(Parse(s)) {
+				return
+			}
+		}
+	}
+}
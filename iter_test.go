@@ -0,0 +1,83 @@
+//go:build go1.23
+
+package version_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+func TestCollection_All(t *testing.T) {
+	versions, err := version.ParseAll([]string{"1.2", "1.0", "2.0rc1"})
+	require.NoError(t, err)
+	c := version.Collection(versions)
+
+	assert.Equal(t, versions, slices.Collect(c.All()))
+
+	var first []string
+	for v := range c.All() {
+		first = append(first, v.String())
+		break
+	}
+	assert.Equal(t, []string{"1.2"}, first)
+	assert.Empty(t, slices.Collect(version.Collection(nil).All()))
+}
+
+func TestVersionSet_All(t *testing.T) {
+	versions, err := version.ParseAll([]string{"1.2", "1.0", "1.0.0", "2.0rc1"})
+	require.NoError(t, err)
+
+	var got []string
+	for v := range version.NewVersionSet(versions...).All() {
+		got = append(got, v.String())
+	}
+	assert.Equal(t, []string{"1.0", "1.2", "2.0rc1"}, got)
+}
+
+func TestSpecifiers_Matching(t *testing.T) {
+	ss, err := version.NewSpecifiers(">=1.0,<2.0")
+	require.NoError(t, err)
+	versions, err := version.ParseAll([]string{"0.9", "1.5", "2.0rc1", "1.0", "2.0", "1.9.post1"})
+	require.NoError(t, err)
+
+	var got []string
+	for v := range ss.Matching(slices.Values(versions)) {
+		got = append(got, v.String())
+	}
+	assert.Equal(t, []string{"1.5", "1.0", "1.9.post1"}, got)
+
+	// Stops pulling candidates once the consumer stops
+	pulled := 0
+	candidates := func(yield func(version.Version) bool) {
+		for _, v := range versions {
+			pulled++
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	for range ss.Matching(candidates) {
+		break
+	}
+	assert.Equal(t, 2, pulled)
+}
+
+func TestParseSeq(t *testing.T) {
+	var got []string
+	var errs int
+	for v, err := range version.ParseSeq(slices.Values([]string{"1.0", "latest", "v2.0"})) {
+		if err != nil {
+			assert.ErrorIs(t, err, version.ErrInvalidVersion)
+			errs++
+			continue
+		}
+		got = append(got, v.String())
+	}
+	assert.Equal(t, []string{"1.0", "2.0"}, got)
+	assert.Equal(t, 1, errs)
+}
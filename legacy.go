@@ -0,0 +1,190 @@
+package version
+
+import (
+	"strings"
+)
+
+// https://github.com/pypa/packaging/blob/21.3/packaging/version.py
+var legacyReplacements = map[string]string{
+	"pre":     "c",
+	"preview": "c",
+	"-":       "final-",
+	"rc":      "c",
+	"dev":     "@",
+}
+
+// AnyVersion is either a Version or a LegacyVersion.
+type AnyVersion interface {
+	String() string
+	Original() string
+	anyVersion()
+}
+
+// LegacyVersion represents a version which is not valid under PEP 440, ordered the way setuptools did
+// before PEP 440 (e.g. 2004d, 0.9-beta-final). All legacy versions sort before all valid versions.
+type LegacyVersion struct {
+	original string
+	key      []string
+}
+
+// ParseLegacy parses the given version with the legacy setuptools rules. It accepts any string.
+func ParseLegacy(v string) LegacyVersion {
+	return LegacyVersion{
+		original: v,
+		key:      legacyCmpkey(v),
+	}
+}
+
+// ParseAny parses the given version as a Version if it is valid under PEP 440, and as a LegacyVersion otherwise.
+func ParseAny(v string) AnyVersion {
+	if ver, err := Parse(v); err == nil {
+		return ver
+	}
+	return ParseLegacy(v)
+}
+
+// CompareAny compares two versions of any kind. Legacy versions sort before all valid versions.
+// It returns -1, 0, or 1 if a is smaller, equal, or larger than b, respectively.
+func CompareAny(a, b AnyVersion) int {
+	switch a := a.(type) {
+	case Version:
+		if b, ok := b.(Version); ok {
+			return a.Compare(b)
+		}
+		return 1
+	case LegacyVersion:
+		if b, ok := b.(LegacyVersion); ok {
+			return a.Compare(b)
+		}
+		return -1
+	}
+	return 0
+}
+
+// ref. https://github.com/pypa/packaging/blob/21.3/packaging/version.py
+func legacyCmpkey(v string) []string {
+	var parts []string
+	for _, p := range legacyVersionParts(strings.ToLower(v)) {
+		if strings.HasPrefix(p, "*") {
+			// remove "-" before a prerelease tag
+			if p < "*final" {
+				for len(parts) > 0 && parts[len(parts)-1] == "*final-" {
+					parts = parts[:len(parts)-1]
+				}
+			}
+
+			// remove trailing zeros from each series of numeric parts
+			for len(parts) > 0 && parts[len(parts)-1] == "00000000" {
+				parts = parts[:len(parts)-1]
+			}
+		}
+		parts = append(parts, p)
+	}
+	return parts
+}
+
+func legacyVersionParts(v string) []string {
+	var parts []string
+	for _, p := range legacySplit(v) {
+		if r, ok := legacyReplacements[p]; ok {
+			p = r
+		}
+		if p == "" || p == "." {
+			continue
+		}
+
+		if p[0] >= '0' && p[0] <= '9' {
+			// pad for numeric comparison
+			if len(p) < 8 {
+				p = strings.Repeat("0", 8-len(p)) + p
+			}
+			parts = append(parts, p)
+		} else {
+			// ensure that alpha/dash/etc. parts sort before numeric ones
+			parts = append(parts, "*"+p)
+		}
+	}
+
+	// ensure that alpha/beta/candidate are before final
+	return append(parts, "*final")
+}
+
+// legacySplit splits v into runs of digits, runs of letters, "." and "-".
+// Any other characters between them are kept as separate parts.
+func legacySplit(v string) []string {
+	var parts []string
+	for i := 0; i < len(v); {
+		j := i + 1
+		switch c := v[i]; {
+		case isDigit(c):
+			for j < len(v) && isDigit(v[j]) {
+				j++
+			}
+		case isLetter(c):
+			for j < len(v) && isLetter(v[j]) {
+				j++
+			}
+		case c == '.' || c == '-':
+		default:
+			for j < len(v) && !isDigit(v[j]) && !isLetter(v[j]) && v[j] != '.' && v[j] != '-' {
+				j++
+			}
+		}
+		parts = append(parts, v[i:j])
+		i = j
+	}
+	return parts
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+// Compare compares this version to another legacy version. This
+// returns -1, 0, or 1 if this version is smaller, equal,
+// or larger than the other version, respectively.
+func (v LegacyVersion) Compare(other LegacyVersion) int {
+	for i := 0; i < len(v.key) && i < len(other.key); i++ {
+		if c := strings.Compare(v.key[i], other.key[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.key) < len(other.key):
+		return -1
+	case len(v.key) > len(other.key):
+		return 1
+	}
+	return 0
+}
+
+// Equal tests if two legacy versions are equal.
+func (v LegacyVersion) Equal(o LegacyVersion) bool {
+	return v.Compare(o) == 0
+}
+
+// GreaterThan tests if this version is greater than another legacy version.
+func (v LegacyVersion) GreaterThan(o LegacyVersion) bool {
+	return v.Compare(o) > 0
+}
+
+// LessThan tests if this version is less than another legacy version.
+func (v LegacyVersion) LessThan(o LegacyVersion) bool {
+	return v.Compare(o) < 0
+}
+
+// String returns the original version
+func (v LegacyVersion) String() string {
+	return v.original
+}
+
+// Original returns the original version
+func (v LegacyVersion) Original() string {
+	return v.original
+}
+
+func (LegacyVersion) anyVersion() {}
@@ -0,0 +1,83 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+func TestLegacyVersion_Compare(t *testing.T) {
+	// in ascending order
+	versions := []string{
+		"foobar",
+		"0.9-beta",
+		"0.9-beta-final",
+		"0.9",
+		"1.0dev",
+		"1.0a",
+		"1.0beta2",
+		"1.0pre1",
+		"1.0",
+		"1.0-1",
+		"2004d",
+		"2004e",
+	}
+	for i, v1 := range versions {
+		for j, v2 := range versions {
+			t.Run(v1+" "+v2, func(t *testing.T) {
+				got := version.ParseLegacy(v1).Compare(version.ParseLegacy(v2))
+				switch {
+				case i < j:
+					assert.Equal(t, -1, got)
+				case i > j:
+					assert.Equal(t, 1, got)
+				default:
+					assert.Equal(t, 0, got)
+				}
+			})
+		}
+	}
+
+	assert.True(t, version.ParseLegacy("1.0rc1").Equal(version.ParseLegacy("1.0c1")))
+	assert.True(t, version.ParseLegacy("1.0.0").Equal(version.ParseLegacy("1.0")))
+}
+
+func TestParseAny(t *testing.T) {
+	tests := []struct {
+		version    string
+		wantLegacy bool
+	}{
+		{"1.0", false},
+		{"1.0rc1", false},
+		{"2004d", true},
+		{"0.9-beta-final", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := version.ParseAny(tt.version)
+			_, ok := v.(version.LegacyVersion)
+			assert.Equal(t, tt.wantLegacy, ok)
+			assert.Equal(t, tt.version, v.Original())
+		})
+	}
+}
+
+func TestCompareAny(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0", "2.0", -1},
+		{"2004d", "0.1", -1},
+		{"0.1", "2004d", 1},
+		{"2004d", "2004e", -1},
+		{"2004d", "2004d", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, version.CompareAny(version.ParseAny(tt.a), version.ParseAny(tt.b)))
+		})
+	}
+}
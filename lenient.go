@@ -0,0 +1,63 @@
+package version
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type lenientRepair struct {
+	regexp      *regexp.Regexp
+	replacement string
+}
+
+// lenientRepairs are applied in order to versions which are not valid PEP 440 versions.
+var lenientRepairs = sync.OnceValue(func() []lenientRepair {
+	return []lenientRepair{
+		// Maven/Java style release qualifiers, e.g. 1.0.0.RELEASE, 1.2.3-final, 2.0.GA
+		{regexp.MustCompile(`(?i)[-_.]?(release|final|ga|stable)$`), ""},
+		// Snapshots are development releases, e.g. 1.0-SNAPSHOT
+		{regexp.MustCompile(`(?i)[-_.]?snapshot$`), ".dev0"},
+		// Semver style dot-separated pre-release identifiers, e.g. 1.2.3-beta.1.2 -> 1.2.3-beta.1
+		{regexp.MustCompile(`(?i)-(a|b|c|rc|alpha|beta|pre|preview)\.([0-9]+)(?:\.[0-9]+)+`), "-$1.$2"},
+	}
+})
+
+// ParseLenient is like Parse, but repairs common non-conforming versions seen in the wild,
+// such as 1.0.0.RELEASE, 1.2.3-final, 1.0-SNAPSHOT or 1.2.3-beta.1.2, into the closest PEP 440 version.
+// repaired reports whether the given version had to be repaired.
+func ParseLenient(v string) (ver Version, repaired bool, err error) {
+	if ver, err = Parse(v); err == nil {
+		return ver, false, nil
+	}
+
+	ver, rerr := Parse(repairVersion(v))
+	if rerr != nil {
+		return Version{}, false, err
+	}
+	ver.original = v
+
+	return ver, true, nil
+}
+
+// repairVersion applies lenientRepairs to the given version.
+func repairVersion(v string) string {
+	s := strings.TrimSpace(v)
+	for _, r := range lenientRepairs() {
+		s = r.regexp.ReplaceAllString(s, r.replacement)
+	}
+	return s
+}
+
+// suggestVersion returns the normalized repaired version if the given malformed version can be repaired.
+func suggestVersion(v string) string {
+	r := repairVersion(v)
+	if r == v {
+		return ""
+	}
+	ver, err := Parse(r)
+	if err != nil {
+		return ""
+	}
+	return ver.String()
+}
@@ -0,0 +1,42 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+func TestParseLenient(t *testing.T) {
+	tests := []struct {
+		version      string
+		want         string
+		wantRepaired bool
+		wantErr      bool
+	}{
+		{version: "1.2.3", want: "1.2.3"},
+		{version: "1.2.3-beta.1", want: "1.2.3b1"},
+		{version: "1.0.0.RELEASE", want: "1.0.0", wantRepaired: true},
+		{version: "1.2.3-final", want: "1.2.3", wantRepaired: true},
+		{version: "2.0.GA ", want: "2.0", wantRepaired: true},
+		{version: "1.0-SNAPSHOT", want: "1.0.dev0", wantRepaired: true},
+		{version: "1.2.3-beta.1.2", want: "1.2.3b1", wantRepaired: true},
+		{version: "1.2.3-rc.1.final", want: "1.2.3rc1", wantRepaired: true},
+		{version: "french toast", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, repaired, err := version.ParseLenient(tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.wantRepaired, repaired)
+			assert.Equal(t, tt.version, got.Original())
+		})
+	}
+}
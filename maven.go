@@ -0,0 +1,132 @@
+package version
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// ParseMavenRange parses a version range in the bracket notation of Maven, e.g. "[1.0,2.0)", "(,1.5]", "[1.5]" or
+// "(,1.0],[1.2,)" for a union of ranges. A range compares versions in their order, so the result is written like
+// FromRanges, e.g. ">=1.0,<2.0" with PreReleaseAllow. It returns ErrInvalidSpecifier if the range is malformed, e.g.
+// a bare version which Maven takes as a soft requirement, ErrInvalidVersion for a version which isn't valid, and
+// the error of FromRanges if the versions can't be written as specifiers.
+func ParseMavenRange(s string) (Specifiers, error) {
+	rest := strings.Join(strings.Fields(s), "")
+	var intervals []Interval
+	for rest != "" {
+		end := strings.IndexAny(rest, "])")
+		if end < 0 || (rest[0] != '[' && rest[0] != '(') {
+			return Specifiers{}, xerrors.Errorf("Maven range %q: %w", s, ErrInvalidSpecifier)
+		}
+		i, err := parseMavenInterval(rest[:end+1])
+		if err != nil {
+			return Specifiers{}, xerrors.Errorf("Maven range %q: %w", s, err)
+		}
+		intervals = append(intervals, i)
+
+		rest = rest[end+1:]
+		if next, ok := strings.CutPrefix(rest, ","); ok {
+			if next == "" {
+				return Specifiers{}, xerrors.Errorf("Maven range %q: trailing comma: %w", s, ErrInvalidSpecifier)
+			}
+			rest = next
+		}
+	}
+	if len(intervals) == 0 {
+		return Specifiers{}, xerrors.Errorf("Maven range %q: %w", s, ErrInvalidSpecifier)
+	}
+
+	ss, err := FromRanges(intervals)
+	if err != nil {
+		return Specifiers{}, xerrors.Errorf("Maven range %q: %w", s, err)
+	}
+	return ss, nil
+}
+
+// parseMavenInterval parses a range like "[1.0,2.0)".
+func parseMavenInterval(r string) (Interval, error) {
+	lowerInclusive, upperInclusive := r[0] == '[', r[len(r)-1] == ']'
+	lower, upper, comma := strings.Cut(r[1:len(r)-1], ",")
+	if !comma {
+		// A single version must be exact like "[1.5]"
+		if !lowerInclusive || !upperInclusive || lower == "" {
+			return Interval{}, xerrors.Errorf("range %q: %w", r, ErrInvalidSpecifier)
+		}
+		upper = lower
+	}
+
+	var i Interval
+	for _, b := range []struct {
+		bound     *Bound
+		version   string
+		inclusive bool
+	}{{&i.Lower, lower, lowerInclusive}, {&i.Upper, upper, upperInclusive}} {
+		if b.version == "" {
+			if b.inclusive {
+				return Interval{}, xerrors.Errorf("range %q: unbounded inclusive end: %w", r, ErrInvalidSpecifier)
+			}
+			*b.bound = Bound{Unbounded: true}
+			continue
+		}
+		v, err := Parse(b.version)
+		if err != nil {
+			return Interval{}, xerrors.Errorf("range %q: %w", r, err)
+		}
+		*b.bound = Bound{Version: v, Inclusive: b.inclusive}
+	}
+	return i, nil
+}
+
+// MavenRange returns the versions satisfying the specifiers in the bracket notation of Maven, e.g. "[1.0,2.0)"
+// for ">=1.0,<2.0" with PreReleaseAllow and "(,1.5),(1.5,)" for "!=1.5". Like Events, the local versions of
+// a version are treated like it, e.g. "(,2.0]" includes 2.0+local. It returns ErrUnrepresentable like Events,
+// e.g. for ">2.0" which excludes the post-releases of 2.0, and for no versions.
+func (ss Specifiers) MavenRange() (string, error) {
+	switch {
+	case ss.conf.preRelease == PreReleaseDeny || ss.conf.preRelease == PreReleaseAuto:
+		return "", xerrors.Errorf("Maven range of %q with the pre-release policy %d: %w",
+			ss, ss.conf.preRelease, ErrUnrepresentable)
+	case slices.ContainsFunc(slices.Concat(ss.specifiers...), ss.conf.inexact):
+		return "", xerrors.Errorf("Maven range of %q: %w", ss, ErrUnrepresentable)
+	}
+
+	spans := ss.spans()
+	if len(spans) == 0 {
+		return "", xerrors.Errorf("Maven range of %q: no versions: %w", ss, ErrUnrepresentable)
+	}
+
+	ranges := make([]string, len(spans))
+	for i, s := range spans {
+		if s.lo.kind == beforeVersion && (s.hi.kind == afterVersion || s.hi.kind == afterLocals) && s.lo.v.Equal(s.hi.v) {
+			ranges[i] = "[" + s.lo.v.String() + "]"
+			continue
+		}
+
+		var sb strings.Builder
+		switch {
+		case s.lo.lowest():
+			sb.WriteString("(")
+		case s.lo.kind == beforeVersion:
+			sb.WriteString("[" + s.lo.v.String())
+		case s.lo.kind == afterVersion, s.lo.kind == afterLocals:
+			sb.WriteString("(" + s.lo.v.String())
+		default:
+			return "", xerrors.Errorf("Maven range of %q: no smallest version in %s: %w", ss, s.interval(), ErrUnrepresentable)
+		}
+		sb.WriteString(",")
+		switch s.hi.kind {
+		case aboveAll:
+			sb.WriteString(")")
+		case beforeVersion:
+			sb.WriteString(s.hi.v.String() + ")")
+		case afterVersion, afterLocals:
+			sb.WriteString(s.hi.v.String() + "]")
+		default:
+			return "", xerrors.Errorf("Maven range of %q: no largest version in %s: %w", ss, s.interval(), ErrUnrepresentable)
+		}
+		ranges[i] = sb.String()
+	}
+	return strings.Join(ranges, ","), nil
+}
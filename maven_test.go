@@ -0,0 +1,88 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMavenRange(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: "[1.0,2.0)", want: ">=1.0,<2.0"},
+		{input: "(,1.5]", want: "<=1.5"},
+		{input: "[1.5]", want: "==1.5"},
+		{input: "[1.0,)", want: ">=1.0"},
+		{input: "(1.0.post1,2.0rc1)", want: ">=1.0.post2.dev0,<2.0rc1"},
+		{input: "(,1.0], [1.2,)", want: "<=1.0||>=1.2"},
+		{input: "(,1.5),(1.5,)", want: "!=1.5"},
+		{input: "(,)", want: ">=0.dev0"},
+		{input: "1.0", wantErr: ErrInvalidSpecifier},
+		{input: "", wantErr: ErrInvalidSpecifier},
+		{input: "[1.0,2.0", wantErr: ErrInvalidSpecifier},
+		{input: "(1.0)", wantErr: ErrInvalidSpecifier},
+		{input: "[,1.0]", wantErr: ErrInvalidSpecifier},
+		{input: "[1.0,2.0),", wantErr: ErrInvalidSpecifier},
+		{input: "[1.0,2.0)[3.0,)", want: ">=1.0,<2.0||>=3.0"},
+		{input: "[1.x,2.0)", wantErr: ErrInvalidVersion},
+		{input: "[2.0,1.0]", wantErr: ErrUnrepresentable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMavenRange(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestSpecifiers_MavenRange(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		policy     PreReleasePolicy
+		want       string
+		wantErr    error
+	}{
+		{specifiers: ">=1.0,<2.0", policy: PreReleaseAllow, want: "[1.0,2.0)"},
+		{specifiers: "<=1.5", want: "(,1.5]"},
+		{specifiers: "==1.5", want: "[1.5]"},
+		{specifiers: "!=1.5", policy: PreReleaseAllow, want: "(,1.5),(1.5,)"},
+		{specifiers: "<=1.0||>=1.2", want: "(,1.0],[1.2,)"},
+		{specifiers: "==1.*", want: "[1.dev0,2.dev0)"},
+		{specifiers: ">=1.0.post2", want: "[1.0.post2,)"},
+		{specifiers: ">=0.dev0", want: "(,)"},
+		{specifiers: ">2.0", wantErr: ErrUnrepresentable},
+		{specifiers: ">1.0.post1", wantErr: ErrUnrepresentable},
+		{specifiers: ">=2.0,<1.0", wantErr: ErrUnrepresentable},
+		{specifiers: "===1.0", wantErr: ErrUnrepresentable},
+		{specifiers: "<2.0", policy: PreReleaseAuto, wantErr: ErrUnrepresentable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers, WithPreReleasePolicy(tt.policy))
+			require.NoError(t, err)
+
+			got, err := ss.MavenRange()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			parsed, err := ParseMavenRange(got)
+			require.NoError(t, err)
+			for _, v := range []string{"0.9", "1.0", "1.0.post1", "1.0.post2", "1.2", "1.5rc1", "1.5", "1.5.1", "2.0rc1", "2.0"} {
+				assert.Equal(t, ss.Check(MustParse(v)), parsed.Check(MustParse(v)), "%s as %s: %s", ss, parsed, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package version
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	// A hyphen range of npm, e.g. "1.2.3 - 2.3.4"
+	npmHyphenRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`^(\S+)\s+-\s+(\S+)$`)
+	})
+
+	// The whitespace allowed after the operators of npm comparators, e.g. ">= 1.2.3"
+	npmOperatorSpaceRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`(<=|>=|~>|[<>=~^])\s+`)
+	})
+
+	// A partial version of npm, e.g. "1", "1.2.x" or "*"
+	npmPartialRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`^v?(?:[xX*]|\d+)(?:\.(?:[xX*]|\d+)){0,2}$`)
+	})
+)
+
+// npmOperators are the operators of npm comparators, longest first.
+var npmOperators = []string{"<=", ">=", "~>", "<", ">", "=", "~", "^"}
+
+// ParseNPMRange translates a range of npm (node-semver) into specifiers on a best-effort basis, e.g. "^1.2.3" into
+// ">=1.2.3,<2", "~1.2" into ">=1.2,<1.3", "1.x" into ">=1,<2" and "1.2.3 - 2.3" into ">=1.2.3,<2.4". Like npm,
+// the result excludes pre-releases unless a comparator mentions one, and the pre-release tags are read as PEP 440
+// ones, e.g. "1.0.0-beta.1" as 1.0.0b1, while build metadata is ignored. It returns ErrInvalidSpecifier if the range
+// is malformed or matches no version like "<*", and ErrInvalidVersion for a version which isn't valid.
+func ParseNPMRange(s string) (Specifiers, error) {
+	groups := strings.Split(s, "||")
+	for i, g := range groups {
+		g = strings.TrimSpace(g)
+
+		var clauses []string
+		if m := npmHyphenRegexp().FindStringSubmatch(g); m != nil {
+			lower, err := npmClauses(">=", m[1])
+			if err != nil {
+				return Specifiers{}, xerrors.Errorf("npm range %q: %w", s, err)
+			}
+			upper, err := npmClauses("<=", m[2])
+			if err != nil {
+				return Specifiers{}, xerrors.Errorf("npm range %q: %w", s, err)
+			}
+			clauses = append(lower, upper...)
+		} else {
+			for _, c := range strings.Fields(npmOperatorSpaceRegexp().ReplaceAllString(g, "$1")) {
+				op := ""
+				for _, o := range npmOperators {
+					if strings.HasPrefix(c, o) {
+						op = o
+						break
+					}
+				}
+				cs, err := npmClauses(op, c[len(op):])
+				if err != nil {
+					return Specifiers{}, xerrors.Errorf("npm range %q: %w", s, err)
+				}
+				clauses = append(clauses, cs...)
+			}
+		}
+
+		if len(clauses) == 0 {
+			// Every version but the pre-releases, like "*"
+			clauses = append(clauses, ">=0")
+		}
+		groups[i] = strings.Join(clauses, ",")
+	}
+	return NewSpecifiers(strings.Join(groups, "||"))
+}
+
+// npmClauses returns the PEP 440 clauses of an npm comparator. No clauses mean every version.
+func npmClauses(op, raw string) ([]string, error) {
+	if op == "~>" {
+		op = "~"
+	}
+	// Build metadata doesn't take part in the order of npm versions
+	raw, _, _ = strings.Cut(raw, "+")
+	if raw == "" {
+		return nil, xerrors.Errorf("comparator %q without a version: %w", op, ErrInvalidSpecifier)
+	}
+	if !npmPartialRegexp().MatchString(raw) {
+		// A full version with a pre-release tag
+		v, err := Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		return goVersionClause(op, v.String())
+	}
+
+	// The numbers up to the first wildcard of a partial version
+	var release []string
+	for _, p := range strings.Split(strings.TrimPrefix(raw, "v"), ".") {
+		if _, err := strconv.ParseUint(p, 10, 64); err != nil {
+			break
+		}
+		release = append(release, p)
+	}
+	if len(release) == 3 {
+		return goVersionClause(op, raw)
+	}
+
+	if len(release) == 0 {
+		switch op {
+		case "<", ">":
+			return nil, xerrors.Errorf("comparator %s%s matching no version: %w", op, raw, ErrInvalidSpecifier)
+		}
+		return nil, nil
+	}
+	v, err := Parse(strings.Join(release, "."))
+	if err != nil {
+		return nil, err
+	}
+	spec, next := v.String(), bumpRelease(v, len(release)-1)
+	switch op {
+	case "", "=":
+		return []string{">=" + spec, "<" + next}, nil
+	case ">":
+		return []string{">=" + next}, nil
+	case ">=":
+		return []string{">=" + spec}, nil
+	case "<":
+		return []string{"<" + spec}, nil
+	case "<=":
+		return []string{"<" + next}, nil
+	}
+	return goVersionClause(op, spec)
+}
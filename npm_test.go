@@ -0,0 +1,61 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNPMRange(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: "^1.2.3", want: ">=1.2.3,<2"},
+		{input: "^0.2.3", want: ">=0.2.3,<0.3"},
+		{input: "^0.0.3", want: ">=0.0.3,<0.0.4"},
+		{input: "^1.x", want: ">=1,<2"},
+		{input: "^0.0.x", want: ">=0.0,<0.1"},
+		{input: "~1.2", want: ">=1.2,<1.3"},
+		{input: "~1.2.3", want: ">=1.2.3,<1.3"},
+		{input: "~>1", want: ">=1,<2"},
+		{input: "1.x", want: ">=1,<2"},
+		{input: "1.2.X", want: ">=1.2,<1.3"},
+		{input: "1.2", want: ">=1.2,<1.3"},
+		{input: "1.2.3", want: "==1.2.3"},
+		{input: "=v1.2.3", want: "==1.2.3"},
+		{input: "*", want: ">=0"},
+		{input: "", want: ">=0"},
+		{input: ">1.2", want: ">=1.3"},
+		{input: "<=1.2", want: "<1.3"},
+		{input: ">= 1.2.3 < 2.0.0", want: ">=1.2.3,<2.0.0"},
+		{input: "1.2.3 - 2.3.4", want: ">=1.2.3,<=2.3.4"},
+		{input: "1.2 - 2.3", want: ">=1.2,<2.4"},
+		{input: "1.2.3 - *", want: ">=1.2.3"},
+		{input: ">=1.0.0-beta.1 <1.0.0", want: ">=1.0.0b1,<1.0.0"},
+		{input: "1.2.3+build.5", want: "==1.2.3"},
+		{input: "^1.2.3 || ~0.9 || 3.x", want: ">=1.2.3,<2||>=0.9,<0.10||>=3,<4"},
+		{input: "<*", wantErr: ErrInvalidSpecifier},
+		{input: ">=", wantErr: ErrInvalidSpecifier},
+		{input: "latest", wantErr: ErrInvalidVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseNPMRange(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+
+	ss, err := ParseNPMRange("^1.2.3")
+	require.NoError(t, err)
+	for v, want := range map[string]bool{"1.2.2": false, "1.2.3": true, "1.9": true, "2.0.0rc1": false, "2.0.0": false} {
+		assert.Equal(t, want, ss.Check(MustParse(v)), v)
+	}
+}
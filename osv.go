@@ -0,0 +1,197 @@
+package version
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Event is an event of an OSV affected range of the ECOSYSTEM type. Exactly one of the versions is set.
+type Event struct {
+	// Introduced is the version the vulnerability was introduced in. 0 means it affects every version.
+	Introduced *Version
+	// Fixed is the version the vulnerability was fixed in, which is not affected.
+	Fixed *Version
+	// LastAffected is the last version affected by the vulnerability.
+	LastAffected *Version
+}
+
+// RangeFromEvents returns the specifiers satisfied by the versions affected by a vulnerability introduced in
+// introduced and fixed in fixed, e.g. ">=1.0,<1.2" for 1.0 and 1.2. Either may be nil for an open range, and an
+// introduced version of 0 or a version without a release segment like the zero Version is treated like nil.
+func RangeFromEvents(introduced, fixed *Version) (Specifiers, error) {
+	events := []Event{{Introduced: introduced}}
+	if introduced == nil || len(introduced.release) == 0 {
+		zero := MustParse("0")
+		events[0].Introduced = &zero
+	}
+	if fixed != nil && len(fixed.release) != 0 {
+		events = append(events, Event{Fixed: fixed})
+	}
+
+	ss, err := RangesFromEvents(events)
+	if err != nil {
+		return Specifiers{}, xerrors.Errorf("range from %v to %v: %w", introduced, fixed, err)
+	}
+	return ss, nil
+}
+
+// RangesFromEvents returns the specifiers satisfied by the versions affected according to OSV events, with an OR
+// group per affected range, e.g. "<1.2||>=2.0,<=2.3" for introduced 0, fixed 1.2, introduced 2.0 and
+// last_affected 2.3. The events needn't be sorted. Like in OSV, a range includes every version below its fixed
+// version, so the result uses PreReleaseAllow if a fixed version isn't a pre-release, e.g. 1.2rc1 is affected if
+// 1.2 is the fix. It returns an empty Specifiers if no version is affected, and ErrInvalidVersion for an event
+// without a version.
+func RangesFromEvents(events []Event) (Specifiers, error) {
+	sorted := slices.Clone(events)
+	for _, e := range sorted {
+		if v := e.version(); v == nil || len(v.release) == 0 {
+			return Specifiers{}, xerrors.Errorf("event without a version: %w", ErrInvalidVersion)
+		}
+	}
+	slices.SortStableFunc(sorted, func(a, b Event) int {
+		return a.version().Compare(*b.version())
+	})
+
+	// Like the reference algorithm of OSV, the last of the events at a version decides whether the versions above
+	// it are affected, and the last of the introduced and fixed ones decides the version itself.
+	policy := PreReleaseDefault
+	var groups []string
+	var introduced *Version
+	for i := 0; i < len(sorted); {
+		x := sorted[i].version()
+		at, after := introduced != nil, introduced != nil
+		for ; i < len(sorted) && sorted[i].version().Equal(*x); i++ {
+			after = sorted[i].Introduced != nil
+			if sorted[i].LastAffected == nil {
+				at = after
+			}
+		}
+
+		switch {
+		case introduced == nil && at:
+			introduced = x
+		case introduced != nil && !at:
+			groups = append(groups, eventGroup(introduced, Event{Fixed: x}))
+			introduced = nil
+			if !x.IsPreRelease() {
+				policy = PreReleaseAllow
+			}
+		}
+		if introduced != nil && !after {
+			groups = append(groups, eventGroup(introduced, Event{LastAffected: x}))
+			introduced = nil
+		}
+	}
+	if introduced != nil {
+		groups = append(groups, eventGroup(introduced, Event{}))
+	}
+
+	if len(groups) == 0 {
+		return Specifiers{}, nil
+	}
+	ss, err := NewSpecifiers(strings.Join(groups, "||"), WithPreReleasePolicy(policy))
+	if err != nil {
+		return Specifiers{}, xerrors.Errorf("events %v: %w", events, err)
+	}
+	return ss, nil
+}
+
+// Events returns the OSV events of the versions satisfying the specifiers, the inverse of RangesFromEvents, e.g.
+// introduced 0, fixed 1.2, introduced 2.0 and last_affected 2.3 for "<1.2||>=2.0,<=2.3". Like RangesFromEvents,
+// a last_affected version stands for its local versions too. It returns ErrUnrepresentable under PreReleaseDeny and
+// PreReleaseAuto, for clauses matching versions by their spelling like "===1.0", and for ranges without a smallest or
+// a largest affected version, e.g. the versions above every post-release of 2.0 in ">2.0". It returns nil if no
+// version satisfies the specifiers.
+func (ss Specifiers) Events() ([]Event, error) {
+	switch {
+	case ss.conf.preRelease == PreReleaseDeny || ss.conf.preRelease == PreReleaseAuto:
+		return nil, xerrors.Errorf("events of %q with the pre-release policy %d: %w",
+			ss, ss.conf.preRelease, ErrUnrepresentable)
+	case slices.ContainsFunc(slices.Concat(ss.specifiers...), ss.conf.inexact):
+		return nil, xerrors.Errorf("events of %q: %w", ss, ErrUnrepresentable)
+	}
+
+	events, err := eventsFromSpans(ss.spans())
+	if err != nil {
+		return nil, xerrors.Errorf("events of %q: %w", ss, err)
+	}
+	return events, nil
+}
+
+// EventsFromRanges returns the OSV events of the versions in the intervals, e.g. introduced 1.0 and fixed 2.0 for
+// [1.0, 2.0). Like FromRanges, the local versions of a public bound are treated like the bound, e.g. (1.0, 2.0]
+// starts with introduced 1.0.post0.dev0. It returns ErrUnrepresentable like Specifiers.Events, and nil for empty
+// intervals.
+func EventsFromRanges(intervals []Interval) ([]Event, error) {
+	spans := make([]cutSpan, 0, len(intervals))
+	for _, i := range intervals {
+		spans = append(spans, i.publicSpan())
+	}
+	events, err := eventsFromSpans(normalizeSpans(spans))
+	if err != nil {
+		return nil, xerrors.Errorf("events of %v: %w", intervals, err)
+	}
+	return events, nil
+}
+
+// eventsFromSpans returns the events of the versions in the sorted, disjoint spans.
+func eventsFromSpans(spans cutSpans) ([]Event, error) {
+	var events []Event
+	for _, s := range spans {
+		var introduced Version
+		switch s.lo.kind {
+		case belowAll:
+			introduced = MustParse("0")
+		case beforeVersion:
+			introduced = s.lo.v
+		case afterLocals:
+			introduced = s.lo.v.Successor()
+		default:
+			return nil, xerrors.Errorf("no smallest version in %s: %w", s.interval(), ErrUnrepresentable)
+		}
+		events = append(events, Event{Introduced: &introduced})
+
+		end := s.hi.v
+		switch s.hi.kind {
+		case aboveAll:
+		case beforeVersion:
+			events = append(events, Event{Fixed: &end})
+		case afterVersion, afterLocals:
+			events = append(events, Event{LastAffected: &end})
+		default:
+			return nil, xerrors.Errorf("no largest version in %s: %w", s.interval(), ErrUnrepresentable)
+		}
+	}
+	return events, nil
+}
+
+func (e Event) version() *Version {
+	switch {
+	case e.Introduced != nil:
+		return e.Introduced
+	case e.Fixed != nil:
+		return e.Fixed
+	}
+	return e.LastAffected
+}
+
+// eventGroup returns the clauses of the range from the introduced version to the end event.
+func eventGroup(introduced *Version, end Event) string {
+	var clauses []string
+	if !introduced.Equal(MustParse("0")) {
+		clauses = append(clauses, ">="+introduced.Public())
+	}
+	switch {
+	case end.Fixed != nil:
+		clauses = append(clauses, "<"+end.Fixed.Public())
+	case end.LastAffected != nil:
+		clauses = append(clauses, "<="+end.LastAffected.Public())
+	}
+	if len(clauses) == 0 {
+		// Every version, as 0.dev0 is the lowest one
+		clauses = append(clauses, ">=0.dev0")
+	}
+	return strings.Join(clauses, ",")
+}
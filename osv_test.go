@@ -0,0 +1,301 @@
+package version
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeFromEvents(t *testing.T) {
+	version := func(s string) *Version {
+		if s == "" {
+			return nil
+		}
+		v := MustParse(s)
+		return &v
+	}
+	tests := []struct {
+		introduced, fixed string
+		want              string
+		policy            PreReleasePolicy
+	}{
+		{introduced: "1.0", fixed: "1.2", want: ">=1.0,<1.2", policy: PreReleaseAllow},
+		{introduced: "1.0", fixed: "1.2rc1", want: ">=1.0,<1.2rc1"},
+		{introduced: "0", fixed: "1.2", want: "<1.2", policy: PreReleaseAllow},
+		{fixed: "1.2", want: "<1.2", policy: PreReleaseAllow},
+		{introduced: "1.0", want: ">=1.0"},
+		{want: ">=0.dev0"},
+		{introduced: "1.0+local", fixed: "1.2+local", want: ">=1.0,<1.2", policy: PreReleaseAllow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.introduced+" "+tt.fixed, func(t *testing.T) {
+			got, err := RangeFromEvents(version(tt.introduced), version(tt.fixed))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.policy, got.conf.preRelease)
+		})
+	}
+
+	// Versions without a release segment are treated like nil
+	fixed := MustParse("1.2")
+	for _, tt := range []struct {
+		introduced, fixed *Version
+		want              string
+	}{
+		{&Version{}, &fixed, "<1.2"},
+		{&NegInf, &Inf, ">=0.dev0"},
+	} {
+		got, err := RangeFromEvents(tt.introduced, tt.fixed)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got.String())
+	}
+}
+
+func TestRangesFromEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []string
+		want    string
+		wantErr bool
+	}{
+		{name: "fixed", events: []string{"introduced 0", "fixed 1.2"}, want: "<1.2"},
+		{name: "last affected", events: []string{"introduced 2.0", "last_affected 2.3"}, want: ">=2.0,<=2.3"},
+		{
+			name:   "unsorted",
+			events: []string{"last_affected 2.3", "fixed 1.2", "introduced 2.0", "introduced 0"},
+			want:   "<1.2||>=2.0,<=2.3",
+		},
+		{name: "open", events: []string{"introduced 1.0", "fixed 1.2", "introduced 1.5"}, want: ">=1.0,<1.2||>=1.5"},
+		{name: "repeated", events: []string{"introduced 1.0", "introduced 1.1", "fixed 1.2", "fixed 1.3"}, want: ">=1.0,<1.2"},
+		{name: "fixed first", events: []string{"fixed 0.9", "introduced 1.0"}, want: ">=1.0"},
+		{name: "nothing affected", events: []string{"fixed 1.0"}},
+		{name: "no events"},
+		{name: "no version", events: []string{"introduced "}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RangesFromEvents(parseEvents(t, tt.events))
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidVersion)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestRangesFromEvents_OSV(t *testing.T) {
+	releases, err := ParseAll(affectedReleases)
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewSource(1))
+	kinds := []string{"introduced", "fixed", "last_affected"}
+	for range 1000 {
+		var lines []string
+		for range r.Intn(5) {
+			lines = append(lines, kinds[r.Intn(len(kinds))]+" "+affectedReleases[r.Intn(len(affectedReleases))])
+		}
+		if r.Intn(2) == 0 {
+			lines = append(lines, "introduced 0")
+		}
+		events := parseEvents(t, lines)
+
+		got, err := RangesFromEvents(events)
+		require.NoError(t, err)
+		for _, v := range releases {
+			require.Equal(t, osvAffected(events, v), got.Check(v), "%s for %v: %s", got, lines, v)
+		}
+	}
+}
+
+// osvAffected evaluates the events like the reference algorithm of the OSV schema.
+func osvAffected(events []Event, v Version) bool {
+	sorted := slices.Clone(events)
+	slices.SortStableFunc(sorted, func(a, b Event) int {
+		return a.version().Compare(*b.version())
+	})
+
+	var affected bool
+	for _, e := range sorted {
+		switch {
+		case e.Introduced != nil:
+			if e.Introduced.Equal(MustParse("0")) || v.Compare(*e.Introduced) >= 0 {
+				affected = true
+			}
+		case e.Fixed != nil:
+			if v.Compare(*e.Fixed) >= 0 {
+				affected = false
+			}
+		case e.LastAffected != nil:
+			if v.Compare(*e.LastAffected) > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+func parseEvents(t *testing.T, lines []string) []Event {
+	var events []Event
+	for _, line := range lines {
+		kind, s, _ := strings.Cut(line, " ")
+		var v *Version
+		if s != "" {
+			parsed := MustParse(s)
+			v = &parsed
+		}
+		switch kind {
+		case "introduced":
+			events = append(events, Event{Introduced: v})
+		case "fixed":
+			events = append(events, Event{Fixed: v})
+		case "last_affected":
+			events = append(events, Event{LastAffected: v})
+		default:
+			t.Fatalf("unknown event %q", line)
+		}
+	}
+	return events
+}
+
+func TestSpecifiers_Events(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		policy     PreReleasePolicy
+		want       []string
+		wantErr    error
+	}{
+		{specifiers: "<1.2", policy: PreReleaseAllow, want: []string{"introduced 0", "fixed 1.2"}},
+		{
+			specifiers: "<1.2||>=2.0,<=2.3",
+			policy:     PreReleaseAllow,
+			want:       []string{"introduced 0", "fixed 1.2", "introduced 2.0", "last_affected 2.3"},
+		},
+		{specifiers: ">=1.0,<1.2rc1", want: []string{"introduced 1.0", "fixed 1.2rc1"}},
+		{specifiers: ">=1.0.post2", want: []string{"introduced 1.0.post2"}},
+		{specifiers: "!=2.0.*", want: []string{"introduced 0", "fixed 2.0.dev0", "introduced 2.1.dev0"}},
+		{specifiers: "==1.3", want: []string{"introduced 1.3", "last_affected 1.3"}},
+		{
+			specifiers: ">=1.0,<2.0,!=1.5",
+			policy:     PreReleaseAllow,
+			want:       []string{"introduced 1.0", "fixed 1.5", "introduced 1.5.post0.dev0", "fixed 2.0"},
+		},
+		{specifiers: ">=2.0,<1.0"},
+		{specifiers: ">2.0", wantErr: ErrUnrepresentable},
+		// >1.0.post1 rejects 1.0.post2+local
+		{specifiers: ">1.0.post1", wantErr: ErrUnrepresentable},
+		{specifiers: "===1.0", wantErr: ErrUnrepresentable},
+		{specifiers: "<1.2", policy: PreReleaseDeny, wantErr: ErrUnrepresentable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers, WithPreReleasePolicy(tt.policy))
+			require.NoError(t, err)
+
+			got, err := ss.Events()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, formatEvents(got))
+		})
+	}
+}
+
+func TestSpecifiers_Events_RoundTrip(t *testing.T) {
+	releases, err := ParseAll(affectedReleases)
+	require.NoError(t, err)
+
+	for _, s := range []string{
+		"<1.2", ">=1.0,<1.2rc1", ">=2.0.post1", "==1.3", ">=1.1,<1.4,!=1.2", "==1.0||>=1.4,<1.5", "<=2.0", "~=1.4", "==1.*",
+	} {
+		for _, policy := range []PreReleasePolicy{PreReleaseDefault, PreReleaseAllow} {
+			ss, err := NewSpecifiers(s, WithPreReleasePolicy(policy))
+			require.NoError(t, err)
+
+			events, err := ss.Events()
+			require.NoError(t, err, s)
+			got, err := RangesFromEvents(events)
+			require.NoError(t, err, s)
+			for _, v := range releases {
+				require.Equal(t, ss.Check(v), got.Check(v), "%s (%d) as %s: %s", s, policy, got, v)
+			}
+		}
+	}
+}
+
+func TestEventsFromRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		intervals []Interval
+		want      []string
+		wantErr   error
+	}{
+		{
+			name: "half-open",
+			intervals: []Interval{{
+				Lower: Bound{Version: MustParse("1.0"), Inclusive: true},
+				Upper: Bound{Version: MustParse("2.0")},
+			}},
+			want: []string{"introduced 1.0", "fixed 2.0"},
+		},
+		{
+			name: "exclusive lower and inclusive upper",
+			intervals: []Interval{{
+				Lower: Bound{Version: MustParse("1.0")},
+				Upper: Bound{Version: MustParse("2.0"), Inclusive: true},
+			}},
+			want: []string{"introduced 1.0.post0.dev0", "last_affected 2.0"},
+		},
+		{
+			name: "unbounded and unsorted",
+			intervals: []Interval{
+				{Lower: Bound{Version: MustParse("3.0"), Inclusive: true}, Upper: Bound{Unbounded: true}},
+				{Lower: Bound{Unbounded: true}, Upper: Bound{Version: MustParse("1.0")}},
+			},
+			want: []string{"introduced 0", "fixed 1.0", "introduced 3.0"},
+		},
+		{
+			name: "after release",
+			intervals: []Interval{{
+				Lower: Bound{Version: MustParse("2.0"), AfterRelease: true},
+				Upper: Bound{Unbounded: true},
+			}},
+			wantErr: ErrUnrepresentable,
+		},
+		{name: "empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EventsFromRanges(tt.intervals)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, formatEvents(got))
+		})
+	}
+}
+
+// formatEvents returns the events in the format of parseEvents.
+func formatEvents(events []Event) []string {
+	var lines []string
+	for _, e := range events {
+		switch {
+		case e.Introduced != nil:
+			lines = append(lines, "introduced "+e.Introduced.String())
+		case e.Fixed != nil:
+			lines = append(lines, "fixed "+e.Fixed.String())
+		case e.LastAffected != nil:
+			lines = append(lines, "last_affected "+e.LastAffected.String())
+		}
+	}
+	return lines
+}
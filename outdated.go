@@ -0,0 +1,87 @@
+package version
+
+import (
+	"slices"
+
+	"golang.org/x/xerrors"
+)
+
+// CandidateSource provides the available versions of projects, e.g. from a package index.
+type CandidateSource interface {
+	// Candidates returns the available versions of the project in any order.
+	Candidates(project string) ([]Version, error)
+}
+
+// CandidateMap is a CandidateSource of the versions of each project in a map.
+// A project missing from the map has no versions.
+type CandidateMap map[string][]Version
+
+// Candidates returns the versions of the project in the map.
+func (m CandidateMap) Candidates(project string) ([]Version, error) {
+	return m[project], nil
+}
+
+// OutdatedPackage is the report of an installed package by Outdated and OutdatedWithConstraints.
+type OutdatedPackage struct {
+	Name      string
+	Installed Version
+	// LatestMatching is the latest version satisfying the constraint of the package, chosen like Specifiers.Latest,
+	// or the zero Version if none does. Without a constraint, every version matches under PreReleaseDefault, so it is
+	// the latest version including pre-releases.
+	LatestMatching Version
+	// Latest is the latest version overall, or the zero Version if the index has none to consider.
+	Latest Version
+	// Outdated reports whether Latest is greater than the installed version.
+	Outdated bool
+}
+
+// Outdated reports the installed versions of the packages against the versions in the index, with an entry per
+// package sorted by name, like pip list --outdated. Pre-releases and development releases only count as the latest
+// overall if the installed version is one itself, like pip does. It returns the error of the index for the first
+// package it fails for.
+func Outdated(installed map[string]Version, index CandidateSource) ([]OutdatedPackage, error) {
+	return OutdatedWithConstraints(installed, index, nil)
+}
+
+// OutdatedWithConstraints is like Outdated, but the latest matching version of a package with a constraint in
+// constraints satisfies it, e.g. the latest 4.2 release of a package pinned to "~=4.2.0".
+func OutdatedWithConstraints(installed map[string]Version, index CandidateSource,
+	constraints map[string]Specifiers) ([]OutdatedPackage, error) {
+	names := make([]string, 0, len(installed))
+	for name := range installed {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	report := make([]OutdatedPackage, 0, len(names))
+	for _, name := range names {
+		v := installed[name]
+		candidates, err := index.Candidates(name)
+		if err != nil {
+			return nil, xerrors.Errorf("candidates of %s: %w", name, err)
+		}
+
+		latest, ok := latestOverall(v, candidates)
+		p := OutdatedPackage{
+			Name:      name,
+			Installed: v,
+			Latest:    latest,
+			Outdated:  ok && latest.GreaterThan(v),
+		}
+		if ss, ok := constraints[name]; ok {
+			p.LatestMatching, _ = ss.Latest(candidates)
+		} else {
+			p.LatestMatching, _ = LatestAny(candidates)
+		}
+		report = append(report, p)
+	}
+	return report, nil
+}
+
+// latestOverall returns the latest of the candidates, only considering pre-releases if the installed version is one.
+func latestOverall(installed Version, candidates []Version) (Version, bool) {
+	if installed.IsPreRelease() {
+		return LatestAny(candidates)
+	}
+	return LatestStable(candidates)
+}
@@ -0,0 +1,100 @@
+package version
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingSource struct{}
+
+func (failingSource) Candidates(string) ([]Version, error) {
+	return nil, errors.New("index unavailable")
+}
+
+// outdatedRow is an OutdatedPackage as strings for comparison.
+type outdatedRow struct {
+	name, installed, latestMatching, latest string
+	outdated                                bool
+}
+
+func outdatedRows(report []OutdatedPackage) []outdatedRow {
+	var rows []outdatedRow
+	for _, p := range report {
+		rows = append(rows, outdatedRow{p.Name, p.Installed.String(), p.LatestMatching.String(), p.Latest.String(), p.Outdated})
+	}
+	return rows
+}
+
+func outdatedIndex(t *testing.T) (map[string]Version, CandidateMap) {
+	versions := func(vs ...string) []Version {
+		parsed, err := ParseAll(vs)
+		require.NoError(t, err)
+		return parsed
+	}
+	index := CandidateMap{
+		"requests": versions("2.31.0", "2.32.3", "3.0.0rc1", "1.2.3"),
+		"django":   versions("4.2.1", "5.1", "4.2.16", "5.0"),
+		"flask":    versions("3.0.3", "3.1.0.dev1"),
+		"numpy":    versions("2.1.0", "2.2.0rc1"),
+		"urllib3":  versions("2.2.3"),
+	}
+	installed := map[string]Version{
+		"urllib3":  MustParse("2.2.3"),
+		"requests": MustParse("2.31.0"),
+		"django":   MustParse("4.2.1"),
+		"flask":    MustParse("3.0.3"),
+		"numpy":    MustParse("2.2.0b1"),
+		"unknown":  MustParse("1.0"),
+	}
+	return installed, index
+}
+
+func TestOutdated(t *testing.T) {
+	installed, index := outdatedIndex(t)
+	got, err := Outdated(installed, index)
+	require.NoError(t, err)
+
+	// The latest matching versions include pre-releases while the latest ones don't for final releases
+	assert.Equal(t, []outdatedRow{
+		{"django", "4.2.1", "5.1", "5.1", true},
+		{"flask", "3.0.3", "3.1.0.dev1", "3.0.3", false},
+		{"numpy", "2.2.0b1", "2.2.0rc1", "2.2.0rc1", true},
+		{"requests", "2.31.0", "3.0.0rc1", "2.32.3", true},
+		{"unknown", "1.0", "", "", false},
+		{"urllib3", "2.2.3", "2.2.3", "2.2.3", false},
+	}, outdatedRows(got))
+}
+
+func TestOutdatedWithConstraints(t *testing.T) {
+	specifiers := func(s string) Specifiers {
+		ss, err := NewSpecifiers(s)
+		require.NoError(t, err)
+		return ss
+	}
+	installed, index := outdatedIndex(t)
+	constraints := map[string]Specifiers{
+		"django":   specifiers("~=4.2.0"),
+		"flask":    specifiers(">=4.0"),
+		"requests": specifiers(">=2.0, <3.0"),
+	}
+
+	got, err := OutdatedWithConstraints(installed, index, constraints)
+	require.NoError(t, err)
+	assert.Equal(t, []outdatedRow{
+		{"django", "4.2.1", "4.2.16", "5.1", true},
+		// Nothing satisfies the constraint
+		{"flask", "3.0.3", "", "3.0.3", false},
+		{"numpy", "2.2.0b1", "2.2.0rc1", "2.2.0rc1", true},
+		{"requests", "2.31.0", "2.32.3", "2.32.3", true},
+		{"unknown", "1.0", "", "", false},
+		{"urllib3", "2.2.3", "2.2.3", "2.2.3", false},
+	}, outdatedRows(got))
+}
+
+func TestOutdated_Error(t *testing.T) {
+	_, err := Outdated(map[string]Version{"requests": MustParse("2.31.0")}, failingSource{})
+	require.ErrorContains(t, err, "candidates of requests: index unavailable")
+}
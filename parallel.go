@@ -0,0 +1,57 @@
+package version
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// checkBatch is the number of versions checked between looking at the context.
+const checkBatch = 1024
+
+// FilterParallel returns the same versions as Filter. The versions are split into contiguous shards checked by
+// up to workers goroutines, which is worthwhile for very large candidate sets such as every release on an index.
+// If workers is zero or negative, runtime.GOMAXPROCS(0) is used. It returns the context's error if the context
+// is done before all the versions have been checked.
+func (ss Specifiers) FilterParallel(ctx context.Context, versions []Version, workers int) ([]Version, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = max(min(workers, (len(versions)+checkBatch-1)/checkBatch), 1)
+
+	matched := make([]bool, len(versions))
+	shard := (len(versions) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(versions); start += shard {
+		end := min(start+shard, len(versions))
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i += checkBatch {
+				if ctx.Err() != nil {
+					return
+				}
+				batch := versions[i:min(i+checkBatch, end)]
+				copy(matched[i:], ss.CheckAll(batch))
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var filtered []Version
+	for i, v := range versions {
+		if matched[i] {
+			filtered = append(filtered, v)
+		}
+	}
+	if filtered == nil && ss.conf.preRelease == PreReleaseAuto {
+		return ss.preReleaseFallback().FilterParallel(ctx, versions, workers)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,59 @@
+package version_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+func TestSpecifiers_FilterParallel(t *testing.T) {
+	ss, err := version.NewSpecifiers(">=1.0, <2.0, !=1.5.*")
+	require.NoError(t, err)
+
+	var versions, want []version.Version
+	for i := 0; i < 5000; i++ {
+		v := version.MustParse(fmt.Sprintf("%d.%d", i%3, i%10))
+		versions = append(versions, v)
+		if ss.Check(v) {
+			want = append(want, v)
+		}
+	}
+
+	for _, workers := range []int{0, 1, 3, 64} {
+		t.Run(fmt.Sprint(workers), func(t *testing.T) {
+			got, err := ss.FilterParallel(context.Background(), versions, workers)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	assert.Equal(t, want, ss.Filter(versions))
+
+	got, err := ss.FilterParallel(context.Background(), nil, 4)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	// Pre-releases are returned under PreReleaseAuto if nothing else matches
+	auto, err := version.NewSpecifiers(">=1.0", version.WithPreReleasePolicy(version.PreReleaseAuto))
+	require.NoError(t, err)
+	pre := []version.Version{version.MustParse("0.9"), version.MustParse("2.0a1")}
+	got, err = auto.FilterParallel(context.Background(), pre, 2)
+	require.NoError(t, err)
+	assert.Equal(t, pre[1:], got)
+}
+
+func TestSpecifiers_FilterParallel_Canceled(t *testing.T) {
+	ss, err := version.NewSpecifiers(">=1.0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = ss.FilterParallel(ctx, []version.Version{version.MustParse("1.0")}, 2)
+	assert.ErrorIs(t, err, context.Canceled)
+}
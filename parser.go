@@ -0,0 +1,224 @@
+package version
+
+import (
+	"strings"
+)
+
+// span is the position of a component in the input. start is -1 if the component is absent.
+type span struct {
+	start, end int
+}
+
+var noSpan = span{-1, -1}
+
+func (s span) present() bool {
+	return s.start >= 0 && s.start < s.end
+}
+
+// versionMatch holds the positions of the components of a version, like the named groups of the version regex.
+type versionMatch struct {
+	epoch   span
+	release span
+	preL    span
+	preN    span
+	postN1  span
+	postL   span
+	postN2  span
+	devL    span
+	devN    span
+	local   span
+}
+
+var (
+	// The alternatives are tried in the same order as the version regex does.
+	preLetters  = []string{"a", "b", "c", "rc", "alpha", "beta", "pre", "preview"}
+	postLetters = []string{"post", "rev", "r"}
+	devLetters  = []string{"dev"}
+)
+
+// versionParser is a hand-written equivalent of the version regex. It tries the alternatives
+// in the same order as the regex does, so that the same components are matched for any input.
+type versionParser struct {
+	s string
+	m versionMatch
+}
+
+// matchVersion matches the given version and returns the positions of its components.
+func matchVersion(s string) (versionMatch, bool) {
+	p := versionParser{s: s}
+	if !p.parse() {
+		return versionMatch{}, false
+	}
+	return p.m, true
+}
+
+func (p *versionParser) parse() bool {
+	i := p.skipSpaces(0)
+	if i < len(p.s) && (p.s[i] == 'v' || p.s[i] == 'V') {
+		i++
+	}
+
+	// Epoch
+	p.m.epoch = noSpan
+	if j := p.digits(i); j > i && j < len(p.s) && p.s[j] == '!' {
+		p.m.epoch = span{i, j}
+		i = j + 1
+	}
+
+	// Release segment
+	j := p.digits(i)
+	if j == i {
+		return false
+	}
+	for j+1 < len(p.s) && p.s[j] == '.' && isDigit(p.s[j+1]) {
+		j = p.digits(j + 1)
+	}
+	p.m.release = span{i, j}
+
+	return p.pre(j)
+}
+
+// stage is the component that follows another one. It's used instead of a func value so that
+// the parser doesn't escape to the heap.
+type stage int
+
+const (
+	stagePost stage = iota
+	stageDev
+	stageLocal
+)
+
+func (p *versionParser) run(s stage, i int) bool {
+	switch s {
+	case stagePost:
+		return p.post(i)
+	case stageDev:
+		return p.dev(i)
+	default:
+		return p.local(i)
+	}
+}
+
+func (p *versionParser) pre(i int) bool {
+	if p.letterNumber(i, preLetters, &p.m.preL, &p.m.preN, stagePost) {
+		return true
+	}
+	p.m.preL, p.m.preN = noSpan, noSpan
+	return p.post(i)
+}
+
+func (p *versionParser) post(i int) bool {
+	p.m.postN1 = noSpan
+	if i+1 < len(p.s) && p.s[i] == '-' && isDigit(p.s[i+1]) {
+		j := p.digits(i + 1)
+		p.m.postN1 = span{i + 1, j}
+		p.m.postL, p.m.postN2 = noSpan, noSpan
+		if p.dev(j) {
+			return true
+		}
+		p.m.postN1 = noSpan
+	}
+
+	if p.letterNumber(i, postLetters, &p.m.postL, &p.m.postN2, stageDev) {
+		return true
+	}
+	p.m.postL, p.m.postN2 = noSpan, noSpan
+	return p.dev(i)
+}
+
+func (p *versionParser) dev(i int) bool {
+	if p.letterNumber(i, devLetters, &p.m.devL, &p.m.devN, stageLocal) {
+		return true
+	}
+	p.m.devL, p.m.devN = noSpan, noSpan
+	return p.local(i)
+}
+
+// letterNumber matches `[-_\.]?(letters)[-_\.]?([0-9]+)?` at i followed by whatever next matches.
+func (p *versionParser) letterNumber(i int, letters []string, letter, number *span,
+	next stage) bool {
+	starts := [2]int{-1, i}
+	if i < len(p.s) && isSeparator(p.s[i]) {
+		starts[0] = i + 1
+	}
+
+	for _, start := range starts {
+		if start < 0 {
+			continue
+		}
+		for _, l := range letters {
+			end := start + len(l)
+			if end > len(p.s) || !strings.EqualFold(p.s[start:end], l) {
+				continue
+			}
+			*letter = span{start, end}
+
+			numberStarts := [2]int{-1, end}
+			if end < len(p.s) && isSeparator(p.s[end]) {
+				numberStarts[0] = end + 1
+			}
+			for _, ns := range numberStarts {
+				if ns < 0 {
+					continue
+				}
+				ne := p.digits(ns)
+				*number = span{ns, ne}
+				if p.run(next, ne) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (p *versionParser) local(i int) bool {
+	p.m.local = noSpan
+	if i < len(p.s) && p.s[i] == '+' {
+		start := i + 1
+		j := p.alnums(start)
+		if j == start {
+			return false
+		}
+		for j+1 < len(p.s) && isSeparator(p.s[j]) && isAlnum(p.s[j+1]) {
+			j = p.alnums(j + 1)
+		}
+		p.m.local = span{start, j}
+		i = j
+	}
+	return p.skipSpaces(i) == len(p.s)
+}
+
+func (p *versionParser) digits(i int) int {
+	for i < len(p.s) && isDigit(p.s[i]) {
+		i++
+	}
+	return i
+}
+
+func (p *versionParser) alnums(i int) int {
+	for i < len(p.s) && isAlnum(p.s[i]) {
+		i++
+	}
+	return i
+}
+
+func (p *versionParser) skipSpaces(i int) int {
+	for i < len(p.s) && isSpace(p.s[i]) {
+		i++
+	}
+	return i
+}
+
+func isSeparator(c byte) bool {
+	return c == '-' || c == '_' || c == '.'
+}
+
+func isAlnum(c byte) bool {
+	return isDigit(c) || isLetter(c|0x20)
+}
+
+// isSpace reports whether c matches \s in the regexp package
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
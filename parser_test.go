@@ -0,0 +1,102 @@
+package version
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// matchVersionRegexp matches the given version with the version regex, which is the reference
+// for the hand-written parser.
+func matchVersionRegexp(s string) (versionMatch, bool) {
+	matches := versionRegex().FindStringSubmatchIndex(s)
+	if matches == nil {
+		return versionMatch{}, false
+	}
+
+	var m versionMatch
+	spans := map[string]*span{
+		"epoch":   &m.epoch,
+		"release": &m.release,
+		"pre_l":   &m.preL,
+		"pre_n":   &m.preN,
+		"post_n1": &m.postN1,
+		"post_l":  &m.postL,
+		"post_n2": &m.postN2,
+		"dev_l":   &m.devL,
+		"dev_n":   &m.devN,
+		"local":   &m.local,
+	}
+	for i, name := range versionRegex().SubexpNames() {
+		if sp, ok := spans[name]; ok {
+			*sp = span{matches[2*i], matches[2*i+1]}
+		}
+	}
+	return m.normalize(), true
+}
+
+// normalize makes absent and empty components comparable.
+func (m versionMatch) normalize() versionMatch {
+	for _, sp := range []*span{&m.epoch, &m.release, &m.preL, &m.preN, &m.postN1, &m.postL, &m.postN2,
+		&m.devL, &m.devN, &m.local} {
+		if !sp.present() {
+			*sp = noSpan
+		}
+	}
+	return m
+}
+
+var parserInputs = []string{
+	"1", "1.0", "v1.0", "V1.0", "  1.0  ", "\t1.0\n", "1!1.0", "1!", "!1.0",
+	"1.0a", "1.0a1", "1.0.a1", "1.0-a-1", "1.0_alpha_1", "1.0alpha", "1.0ALPHA1", "1.0beta", "1.0b2",
+	"1.0c1", "1.0rc1", "1.0pre1", "1.0preview1", "1.0-preview.1", "1.0rc", "1.0rcdev", "1.0apost1",
+	"1.0-1", "1.0-", "1.0-1-1", "1.0a-1", "1.0a-1-1", "1.0post", "1.0.post1", "1.0-post-1", "1.0rev1",
+	"1.0r1", "1.0r", "1.0rev", "1.0revdev", "1.0prerev1", "1.0.post.", "1.0-r-",
+	"1.0dev", "1.0.dev1", "1.0-dev-1", "1.0.dev.", "1.0dev_", "1.0a1.post2.dev3", "1.0a1-2.dev3",
+	"1.0+abc", "1.0+abc.5", "1.0+ABC-def_5", "1.0+", "1.0+.abc", "1.0+abc.", "1.0+abc..def", "1.0+abc def",
+	"1.0 +abc", "1.0a1+local ", "1.0.", "1..0", ".1.0", "", " ", "v", "vv1.0", "1.0vv", "1.0a1a1",
+	"1.0dev1post1", "1.0post1a1", "1.0-1a1", "1.0alphabeta", "1.0previewx", "1.0-_1", "1.0._post1",
+	"1.0\v", "1.0 \x00", "18446744073709551616", "1.0a18446744073709551616",
+}
+
+func TestMatchVersion(t *testing.T) {
+	for _, input := range parserInputs {
+		t.Run(fmt.Sprintf("%q", input), func(t *testing.T) {
+			want, wantOK := matchVersionRegexp(input)
+			got, gotOK := matchVersion(input)
+			assert.Equal(t, wantOK, gotOK)
+			if gotOK {
+				assert.Equal(t, want, got.normalize())
+			}
+		})
+	}
+}
+
+func FuzzMatchVersion(f *testing.F) {
+	for _, input := range parserInputs {
+		f.Add(input)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		want, wantOK := matchVersionRegexp(input)
+		got, gotOK := matchVersion(input)
+		if wantOK != gotOK {
+			t.Fatalf("matchVersion(%q) = %v, want %v", input, gotOK, wantOK)
+		}
+		if gotOK && want != got.normalize() {
+			t.Fatalf("matchVersion(%q) = %+v, want %+v", input, got.normalize(), want)
+		}
+	})
+}
+
+func BenchmarkMatchVersion(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		matchVersion("1!2.3.4rc5.post6.dev7+ubuntu-1")
+	}
+}
+
+func BenchmarkMatchVersionRegexp(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		versionRegex().FindStringSubmatchIndex("1!2.3.4rc5.post6.dev7+ubuntu-1")
+	}
+}
@@ -0,0 +1,96 @@
+package version
+
+import (
+	"encoding/json"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// PipfileSpecifiers returns the specifiers of a package of a Pipfile, given the value of its field decoded from TOML,
+// e.g. "*", ">=1.0" or a table like {version = ">=1.0", markers = "os_name == 'nt'"}. The markers and the other keys
+// of a table are ignored, and a table without a version, e.g. of a VCS package, allows every version like "*".
+func PipfileSpecifiers(field any, opts ...SpecifierOption) (Specifiers, error) {
+	var s string
+	switch f := field.(type) {
+	case string:
+		s = f
+	case map[string]any:
+		version, ok := f["version"]
+		if !ok {
+			s = "*"
+			break
+		}
+		if s, ok = version.(string); !ok {
+			return Specifiers{}, xerrors.Errorf("Pipfile version %v of type %T: %w", version, version, ErrInvalidSpecifier)
+		}
+	case map[string]string:
+		var ok bool
+		if s, ok = f["version"]; !ok {
+			s = "*"
+		}
+	default:
+		return Specifiers{}, xerrors.Errorf("Pipfile field %v of type %T: %w", field, field, ErrInvalidSpecifier)
+	}
+	return NewSpecifiers(s, opts...)
+}
+
+// PipfileLock is the pinned versions of the packages of a Pipfile.lock by their names.
+type PipfileLock struct {
+	Default map[string]Version
+	Develop map[string]Version
+}
+
+// ParsePipfileLock parses the pins of a Pipfile.lock, e.g. "version": "==2.31.0". The packages without a version,
+// such as VCS ones, are skipped. It returns ErrInvalidVersion if a version isn't pinned with == or ===.
+func ParsePipfileLock(data []byte) (PipfileLock, error) {
+	var raw struct {
+		Default map[string]pipfileLockEntry `json:"default"`
+		Develop map[string]pipfileLockEntry `json:"develop"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return PipfileLock{}, xerrors.Errorf("Pipfile.lock: %w", err)
+	}
+
+	var lock PipfileLock
+	var err error
+	if lock.Default, err = pipfileLockPins(raw.Default); err != nil {
+		return PipfileLock{}, err
+	}
+	if lock.Develop, err = pipfileLockPins(raw.Develop); err != nil {
+		return PipfileLock{}, err
+	}
+	return lock, nil
+}
+
+type pipfileLockEntry struct {
+	Version string `json:"version"`
+}
+
+func pipfileLockPins(entries map[string]pipfileLockEntry) (map[string]Version, error) {
+	pins := make(map[string]Version, len(entries))
+	for name, e := range entries {
+		if e.Version == "" {
+			continue
+		}
+		v, err := parsePin(e.Version)
+		if err != nil {
+			return nil, xerrors.Errorf("Pipfile.lock package %s: %w", name, err)
+		}
+		pins[name] = v
+	}
+	return pins, nil
+}
+
+// parsePin parses the version of a pin like "==2.31.0".
+func parsePin(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	v, ok := strings.CutPrefix(s, string(OpArbitrary))
+	if !ok {
+		v, ok = strings.CutPrefix(s, string(OpEqual))
+	}
+	if !ok {
+		return Version{}, xerrors.Errorf("%q is not pinned: %w", s, ErrInvalidVersion)
+	}
+	return Parse(strings.TrimSpace(v))
+}
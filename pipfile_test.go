@@ -0,0 +1,68 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipfileSpecifiers(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   any
+		want    string
+		wantErr bool
+	}{
+		{name: "any", field: "*", want: ">=0.0.0"},
+		{name: "string", field: ">= 1.0, < 2", want: ">= 1.0,< 2"},
+		{name: "pin", field: "==1.0", want: "==1.0"},
+		{name: "table", field: map[string]any{"version": ">=1.0", "markers": "os_name == 'nt'"}, want: ">=1.0"},
+		{name: "table of strings", field: map[string]string{"version": "~=1.4", "index": "pypi"}, want: "~=1.4"},
+		{name: "vcs", field: map[string]any{"git": "https://example.com/repo.git", "editable": true}, want: ">=0.0.0"},
+		{name: "invalid version type", field: map[string]any{"version": 1}, wantErr: true},
+		{name: "invalid type", field: 1.0, wantErr: true},
+		{name: "invalid specifier", field: ">>1.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PipfileSpecifiers(tt.field)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidSpecifier)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestParsePipfileLock(t *testing.T) {
+	lock, err := ParsePipfileLock([]byte(`{
+		"_meta": {"hash": {"sha256": "abc"}, "pipfile-spec": 6},
+		"default": {
+			"requests": {"hashes": ["sha256:abc"], "index": "pypi", "version": "==2.31.0"},
+			"pywin32": {"markers": "sys_platform == 'win32'", "version": "===306"},
+			"mylib": {"editable": true, "git": "https://example.com/mylib.git", "ref": "abc"}
+		},
+		"develop": {
+			"pytest": {"version": "== 7.4.0"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	assert.Len(t, lock.Default, 2)
+	assert.Equal(t, "2.31.0", lock.Default["requests"].String())
+	assert.Equal(t, "306", lock.Default["pywin32"].String())
+	assert.Len(t, lock.Develop, 1)
+	assert.Equal(t, "7.4.0", lock.Develop["pytest"].String())
+
+	_, err = ParsePipfileLock([]byte(`{"default": {"requests": {"version": ">=2.0"}}}`))
+	require.ErrorIs(t, err, ErrInvalidVersion)
+
+	_, err = ParsePipfileLock([]byte(`{"default": {"requests": {"version": "==2.x"}}}`))
+	require.ErrorIs(t, err, ErrInvalidVersion)
+
+	_, err = ParsePipfileLock([]byte(`not json`))
+	require.Error(t, err)
+}
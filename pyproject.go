@@ -0,0 +1,83 @@
+package version
+
+import (
+	"golang.org/x/xerrors"
+)
+
+// PyProject is the dependencies declared in the [project] table of a pyproject.toml (PEP 621).
+type PyProject struct {
+	// Dependencies are the requirements of project.dependencies.
+	Dependencies []Requirement
+	// OptionalDependencies are the requirements of project.optional-dependencies by their extras.
+	OptionalDependencies map[string][]Requirement
+}
+
+// ParsePyProject returns the dependencies of a pyproject.toml, given the document decoded from TOML into maps
+// and slices, e.g. by github.com/BurntSushi/toml. The dependencies missing from the document, e.g. as they are
+// dynamic, are left empty. It returns ErrInvalidRequirement if the tables or the arrays have unexpected types,
+// and the error of ParseRequirement for a malformed requirement.
+func ParsePyProject(doc map[string]any, opts ...SpecifierOption) (PyProject, error) {
+	var p PyProject
+	project, ok := doc["project"]
+	if !ok {
+		return p, nil
+	}
+	table, ok := project.(map[string]any)
+	if !ok {
+		return PyProject{}, xerrors.Errorf("project of type %T: %w", project, ErrInvalidRequirement)
+	}
+
+	var err error
+	if p.Dependencies, err = parsePyProjectRequirements("project.dependencies", table["dependencies"], opts); err != nil {
+		return PyProject{}, err
+	}
+
+	optional, ok := table["optional-dependencies"]
+	if !ok {
+		return p, nil
+	}
+	extras, ok := optional.(map[string]any)
+	if !ok {
+		return PyProject{}, xerrors.Errorf("project.optional-dependencies of type %T: %w",
+			optional, ErrInvalidRequirement)
+	}
+	p.OptionalDependencies = make(map[string][]Requirement, len(extras))
+	for extra, deps := range extras {
+		key := "project.optional-dependencies." + extra
+		if p.OptionalDependencies[extra], err = parsePyProjectRequirements(key, deps, opts); err != nil {
+			return PyProject{}, err
+		}
+	}
+	return p, nil
+}
+
+// parsePyProjectRequirements parses an array of requirements decoded from TOML.
+func parsePyProjectRequirements(key string, array any, opts []SpecifierOption) ([]Requirement, error) {
+	var lines []string
+	switch a := array.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		lines = a
+	case []any:
+		for _, e := range a {
+			line, ok := e.(string)
+			if !ok {
+				return nil, xerrors.Errorf("%s: element of type %T: %w", key, e, ErrInvalidRequirement)
+			}
+			lines = append(lines, line)
+		}
+	default:
+		return nil, xerrors.Errorf("%s of type %T: %w", key, array, ErrInvalidRequirement)
+	}
+
+	reqs := make([]Requirement, len(lines))
+	for i, line := range lines {
+		r, err := ParseRequirement(line, opts...)
+		if err != nil {
+			return nil, xerrors.Errorf("%s: %w", key, err)
+		}
+		reqs[i] = r
+	}
+	return reqs, nil
+}
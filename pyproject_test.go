@@ -0,0 +1,81 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePyProject(t *testing.T) {
+	doc := map[string]any{
+		"build-system": map[string]any{"requires": []any{"hatchling"}},
+		"project": map[string]any{
+			"name":         "example",
+			"dependencies": []any{"requests>=2.0,<3", "pywin32>=300; os_name == 'nt'"},
+			"optional-dependencies": map[string]any{
+				"test": []any{"pytest~=7.4", "coverage[toml]"},
+				"docs": []string{"sphinx"},
+			},
+		},
+	}
+	got, err := ParsePyProject(doc)
+	require.NoError(t, err)
+
+	var deps []string
+	for _, r := range got.Dependencies {
+		deps = append(deps, r.String())
+	}
+	assert.Equal(t, []string{"requests<3,>=2.0", "pywin32>=300; os_name == 'nt'"}, deps)
+	assert.True(t, got.Dependencies[0].Specifiers.Check(MustParse("2.31.0")))
+
+	optional := make(map[string][]string)
+	for extra, reqs := range got.OptionalDependencies {
+		for _, r := range reqs {
+			optional[extra] = append(optional[extra], r.String())
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"test": {"pytest~=7.4", "coverage[toml]"},
+		"docs": {"sphinx"},
+	}, optional)
+}
+
+func TestParsePyProject_Missing(t *testing.T) {
+	got, err := ParsePyProject(map[string]any{"tool": map[string]any{}})
+	require.NoError(t, err)
+	assert.Empty(t, got.Dependencies)
+
+	got, err = ParsePyProject(map[string]any{"project": map[string]any{"dynamic": []any{"dependencies"}}})
+	require.NoError(t, err)
+	assert.Empty(t, got.Dependencies)
+	assert.Nil(t, got.OptionalDependencies)
+}
+
+func TestParsePyProject_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     map[string]any
+		wantErr error
+	}{
+		{name: "project", doc: map[string]any{"project": "example"}, wantErr: ErrInvalidRequirement},
+		{name: "dependencies", doc: map[string]any{"project": map[string]any{"dependencies": "requests"}}, wantErr: ErrInvalidRequirement},
+		{name: "element", doc: map[string]any{"project": map[string]any{"dependencies": []any{1}}}, wantErr: ErrInvalidRequirement},
+		{
+			name:    "optional",
+			doc:     map[string]any{"project": map[string]any{"optional-dependencies": []any{"pytest"}}},
+			wantErr: ErrInvalidRequirement,
+		},
+		{
+			name:    "specifier",
+			doc:     map[string]any{"project": map[string]any{"optional-dependencies": map[string]any{"test": []any{"pytest>>7"}}}},
+			wantErr: ErrInvalidSpecifier,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePyProject(tt.doc)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
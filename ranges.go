@@ -0,0 +1,535 @@
+package version
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// The range algebra on Specifiers models the versions satisfying a specifier as sorted spans of the ordered versions.
+// It follows the order of versions, so versions comparing as equal (e.g. 1.0 and 1.0.0) are treated alike, and
+// PreReleaseDeny and PreReleaseAuto are treated like PreReleaseDefault. The special cases of the exclusive operators
+// that can't be represented by spans (e.g. >2.0rc1 not matching 2.0rc2.post1) are widened to the enclosing spans.
+
+// cutKind is where a cut lies relative to its version.
+type cutKind int8
+
+const (
+	belowAll      cutKind = iota // below every version
+	beforeVersion                // right below the version
+	afterVersion                 // right above the version, below its local versions
+	afterLocals                  // above the public version and all its local versions
+	afterRelease                 // above every version with the epoch and release segment of the version
+	aboveAll                     // above every version
+)
+
+// A cut splits the ordered versions into those below and those above it, like a Dedekind cut.
+// Cuts can lie where no version does, such as above all the post-releases of a release.
+type cut struct {
+	kind cutKind
+	v    Version
+}
+
+// below returns the cut right below the version. It is normalized so that cuts at the same place are equal,
+// e.g. the cut below 1.0.post0.dev0 is the one above 1.0 and its local versions.
+func below(v Version) cut {
+	if v.local == "" {
+		if p, ok := v.Predecessor(); ok {
+			return cut{kind: afterLocals, v: p}
+		}
+	}
+	return cut{kind: beforeVersion, v: v}
+}
+
+// above returns the cut right above the version, below its local versions.
+func above(v Version) cut {
+	return cut{kind: afterVersion, v: v}
+}
+
+// aboveLocals returns the cut above the public version of v and all its local versions.
+func aboveLocals(v Version) cut {
+	return cut{kind: afterLocals, v: v.withoutLocal()}
+}
+
+// aboveRelease returns the cut above all the versions with the epoch and release segment of v.
+func aboveRelease(v Version) cut {
+	return cut{kind: afterRelease, v: v}
+}
+
+// extreme returns -1 for belowAll, 1 for aboveAll and 0 for the other cuts.
+func (c cut) extreme() int {
+	switch c.kind {
+	case belowAll:
+		return -1
+	case aboveAll:
+		return 1
+	}
+	return 0
+}
+
+func compareCuts(a, b cut) int {
+	if c := cmp.Compare(a.extreme(), b.extreme()); c != 0 || a.extreme() != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.v.epoch, b.v.epoch); c != 0 {
+		return c
+	} else if c = compareRelease(a.v.release, b.v.release); c != 0 {
+		return c
+	} else if a.kind == afterRelease || b.kind == afterRelease {
+		return compareFlags(a.kind == afterRelease, b.kind == afterRelease)
+	}
+
+	if c := compareVersions(a.v.withoutLocal(), b.v.withoutLocal()); c != 0 {
+		return c
+	} else if a.kind == afterLocals || b.kind == afterLocals {
+		return compareFlags(a.kind == afterLocals, b.kind == afterLocals)
+	}
+
+	if c := compareLocal(a.v.local, b.v.local); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.kind, b.kind)
+}
+
+// compareFlags compares the flags as false < true.
+func compareFlags(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
+// cutSpan is the versions above lo and below hi. It is empty unless lo < hi.
+type cutSpan struct {
+	lo, hi cut
+}
+
+func (s cutSpan) isEmpty() bool {
+	return compareCuts(s.lo, s.hi) >= 0
+}
+
+// contains reports whether the version lies in the span.
+func (s cutSpan) contains(v Version) bool {
+	return compareCuts(s.lo, cut{kind: beforeVersion, v: v}) <= 0 && compareCuts(cut{kind: afterVersion, v: v}, s.hi) <= 0
+}
+
+// cutSpans are sorted, disjoint and non-adjacent spans.
+type cutSpans []cutSpan
+
+// allVersions returns the spans of every version.
+func allVersions() cutSpans {
+	return cutSpans{{lo: cut{kind: belowAll}, hi: cut{kind: aboveAll}}}
+}
+
+// normalizeSpans sorts the given spans, dropping the empty ones and merging the overlapping or adjacent ones.
+func normalizeSpans(ss []cutSpan) cutSpans {
+	ss = slices.DeleteFunc(ss, cutSpan.isEmpty)
+	slices.SortFunc(ss, func(a, b cutSpan) int {
+		return compareCuts(a.lo, b.lo)
+	})
+
+	var merged cutSpans
+	for _, s := range ss {
+		if n := len(merged); n > 0 && compareCuts(s.lo, merged[n-1].hi) <= 0 {
+			if compareCuts(s.hi, merged[n-1].hi) > 0 {
+				merged[n-1].hi = s.hi
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+func (ss cutSpans) contains(v Version) bool {
+	for _, s := range ss {
+		if s.contains(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ss cutSpans) union(other cutSpans) cutSpans {
+	return normalizeSpans(append(slices.Clone(ss), other...))
+}
+
+func (ss cutSpans) intersect(other cutSpans) cutSpans {
+	var result cutSpans
+	for i, j := 0, 0; i < len(ss) && j < len(other); {
+		s := cutSpan{lo: maxCut(ss[i].lo, other[j].lo), hi: minCut(ss[i].hi, other[j].hi)}
+		if !s.isEmpty() {
+			result = append(result, s)
+		}
+		if compareCuts(ss[i].hi, other[j].hi) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+func (ss cutSpans) complement() cutSpans {
+	var result cutSpans
+	lo := cut{kind: belowAll}
+	for _, s := range ss {
+		result = append(result, cutSpan{lo: lo, hi: s.lo})
+		lo = s.hi
+	}
+	result = append(result, cutSpan{lo: lo, hi: cut{kind: aboveAll}})
+	return slices.DeleteFunc(result, cutSpan.isEmpty)
+}
+
+// subsetOf reports whether every version in the spans lies in the other spans.
+func (ss cutSpans) subsetOf(other cutSpans) bool {
+	return len(ss.intersect(other.complement())) == 0
+}
+
+func (ss cutSpans) equal(other cutSpans) bool {
+	return ss.subsetOf(other) && other.subsetOf(ss)
+}
+
+func maxCut(a, b cut) cut {
+	if compareCuts(a, b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func minCut(a, b cut) cut {
+	if compareCuts(a, b) <= 0 {
+		return a
+	}
+	return b
+}
+
+// spans returns the spans of the versions satisfying the specifiers.
+func (ss Specifiers) spans() cutSpans {
+	var union cutSpans
+	for _, and := range ss.specifiers {
+		union = union.union(ss.conf.groupSpans(and))
+	}
+	return union
+}
+
+// spans returns the spans of the versions satisfying the clause.
+func (s specifier) spans(allowPreRelease bool) cutSpans {
+	v := s.parsed
+	switch s.op {
+	case OpEqual, OpNotEqual:
+		var eq cutSpans
+		switch {
+		case s.wildcard:
+			eq = prefixSpans(MustParse(strings.TrimSuffix(s.version, ".*")))
+		case v.local != "":
+			eq = cutSpans{{lo: below(v), hi: above(v)}}
+		default:
+			eq = cutSpans{{lo: below(v), hi: aboveLocals(v)}}
+		}
+		if s.op == OpNotEqual {
+			return eq.complement()
+		}
+		return eq
+	case OpCompatible:
+		// ~=2.2.1 is >=2.2.1,==2.2.*, and the pre-release segment counts as the last one like in compatiblePrefix
+		prefix := finalRelease(v)
+		if v.pre.isNull() {
+			prefix = v.Truncate(len(v.release) - 1)
+		}
+		return cutSpans{{lo: below(v), hi: below(releaseStart(prefix.bump(len(prefix.release) - 1)))}}
+	case OpLessThanEqual:
+		return cutSpans{{lo: cut{kind: belowAll}, hi: aboveLocals(v)}}
+	case OpGreaterThanEqual:
+		return cutSpans{{lo: below(v), hi: cut{kind: aboveAll}}}
+	case OpLessThan:
+		if allowPreRelease || v.IsPreRelease() {
+			return cutSpans{{lo: cut{kind: belowAll}, hi: below(v)}}
+		}
+		// The pre-releases of the release of the spec version are excluded
+		return normalizeSpans([]cutSpan{
+			{lo: cut{kind: belowAll}, hi: below(releaseStart(v))},
+			{lo: below(finalRelease(v)), hi: below(v)},
+		})
+	case OpGreaterThan:
+		if v.IsPostRelease() {
+			// Widened to the local versions of the later post-releases of the release, which > rejects
+			return cutSpans{{lo: aboveLocals(v), hi: cut{kind: aboveAll}}}
+		}
+		// The post-releases and the local versions of the release of the spec version are excluded
+		return normalizeSpans([]cutSpan{
+			{lo: aboveLocals(v), hi: above(finalRelease(v))},
+			{lo: aboveRelease(v), hi: cut{kind: aboveAll}},
+		})
+	case OpArbitrary:
+		arbitrary, err := Parse(s.version)
+		if err != nil || !strings.EqualFold(arbitrary.String(), s.version) {
+			return nil
+		}
+		return cutSpans{{lo: below(arbitrary), hi: above(arbitrary)}}
+	}
+	return nil
+}
+
+// prefixSpans returns the spans of the versions matching the prefix p, e.g. 1.0.dev0 to 1.1.dev0 for 1.0.*.
+func prefixSpans(p Version) cutSpans {
+	var next Version
+	switch {
+	case !p.post.isNull():
+		next = p.derive(func(ver *Version) {
+			ver.post.number++
+		})
+	case !p.pre.isNull():
+		next = p.derive(func(ver *Version) {
+			ver.pre.number++
+		})
+	default:
+		p, next = releaseStart(p), releaseStart(p.bump(len(p.release)-1))
+	}
+	return cutSpans{{lo: below(withDev0(p)), hi: below(withDev0(next))}}
+}
+
+// finalRelease returns the final release of the version, e.g. 1.0 for 1.0rc1.post2.
+func finalRelease(v Version) Version {
+	return v.Truncate(len(v.release))
+}
+
+// releaseStart returns the lowest version with the epoch and release segment of v, e.g. 1.0.dev0 for 1.0rc1.
+func releaseStart(v Version) Version {
+	return withDev0(finalRelease(v))
+}
+
+// withDev0 returns the lowest development release of the version, e.g. 1.0rc1.dev0 for 1.0rc1.
+func withDev0(v Version) Version {
+	return v.derive(func(ver *Version) {
+		ver.dev = letterNumber{letter: devQualifier}
+		ver.local = ""
+	})
+}
+
+// IsEmpty reports whether no version can satisfy the specifiers, e.g. ">2.0,<1.0" or "==1.0,!=1.0".
+// Versions comparing as equal are treated alike, so "===1.0,<1.0.0.1" isn't empty though only 1.0 could match,
+// and the pre-release policies other than PreReleaseAllow are treated like PreReleaseDefault.
+func (ss Specifiers) IsEmpty() bool {
+	return len(ss.spans()) == 0
+}
+
+// Intersect returns the simplified specifiers satisfied by exactly the versions satisfying both a and b,
+// e.g. "<2.0,>=1.5" for ">=1.0,<2.0" and ">=1.5". The result is empty (see IsEmpty) if they conflict.
+// It returns ErrIncompatibleSpecifiers if a and b have different pre-release policies.
+func Intersect(a, b Specifiers) (Specifiers, error) {
+	if a.conf.preRelease != b.conf.preRelease {
+		return Specifiers{}, xerrors.Errorf("intersecting %q and %q: %w", a, b, ErrIncompatibleSpecifiers)
+	}
+	return a.And(b).Simplify(), nil
+}
+
+// Union returns the simplified specifiers satisfied by the versions satisfying either a or b, joining their OR groups,
+// e.g. ">=1.0,<3.0" for ">=1.0,<2.0" and ">=1.5,<3.0". The options of a are kept like Or.
+func Union(a, b Specifiers) Specifiers {
+	return a.Or(b).Simplify()
+}
+
+// Overlaps reports whether a version could satisfy both a and b, e.g. ">=1.0,<2.0" overlaps ">=1.5" but not "<1.0".
+// Unlike Intersect, a and b may have different pre-release policies. The approximations of IsEmpty apply,
+// so it is true if in doubt.
+func Overlaps(a, b Specifiers) bool {
+	return len(a.spans().intersect(b.spans())) > 0
+}
+
+// Complement returns the specifiers satisfied by exactly the versions ss rejects, e.g. "<1.0||>=2.0" for ">=1.0,<2.0",
+// written like FromRanges. It returns ErrUnrepresentable if they can't be written as specifiers, e.g. for ">2.0"
+// rejecting every post-release of 2.0, for clauses matching versions by their spelling like "===1.0",
+// or under PreReleaseDeny and PreReleaseAuto, which reject pre-releases no specifier can single out.
+func (ss Specifiers) Complement() (Specifiers, error) {
+	switch {
+	case ss.conf.preRelease == PreReleaseDeny || ss.conf.preRelease == PreReleaseAuto:
+		return Specifiers{}, xerrors.Errorf("complementing %q with the pre-release policy %d: %w",
+			ss, ss.conf.preRelease, ErrUnrepresentable)
+	case slices.ContainsFunc(slices.Concat(ss.specifiers...), ss.conf.inexact):
+		return Specifiers{}, xerrors.Errorf("complementing %q: %w", ss, ErrUnrepresentable)
+	}
+
+	complement, err := fromSpans(ss.spans().complement())
+	if err != nil {
+		return Specifiers{}, xerrors.Errorf("complementing %q: %w", ss, err)
+	}
+	return complement, nil
+}
+
+// SubsetOf reports whether every version satisfying ss satisfies other, e.g. ">=1.2,<1.5" is a subset of
+// ">=1.0,<2.0" and "==1.1.*" of ">=1.0". It is false if in doubt, e.g. when other has clauses matching versions
+// by their spelling like "===1.0".
+func (ss Specifiers) SubsetOf(other Specifiers) bool {
+	if ss.Equal(other) {
+		return true
+	}
+	if slices.ContainsFunc(slices.Concat(other.specifiers...), other.conf.inexact) {
+		return false
+	}
+	return ss.spans().subsetOf(other.spans()) && ss.preReleaseSpans().subsetOf(other.preReleaseSpans())
+}
+
+// preReleaseSpans returns the spans of the pre-releases which may satisfy the specifiers.
+func (ss Specifiers) preReleaseSpans() cutSpans {
+	if ss.conf.preRelease == PreReleaseDeny {
+		return nil
+	}
+	var union cutSpans
+	for _, and := range ss.specifiers {
+		if ss.conf.preRelease != PreReleaseAuto || admitsPreReleases(and) {
+			union = union.union(ss.conf.groupSpans(and))
+		}
+	}
+	return union
+}
+
+// Simplify returns equivalent specifiers without redundant clauses and OR groups, e.g. ">=1.2,<2.0" for
+// ">=1.0, >=1.2, <2.0, !=3.0". OR groups are merged when their union can be written with their own clauses,
+// e.g. ">=1.0,<3.0" for ">=1.0,<2.0 || >=1.5,<3.0". The remaining clauses are kept as written.
+func (ss Specifiers) Simplify() Specifiers {
+	groups := make([][]specifier, 0, len(ss.specifiers))
+	for _, and := range ss.specifiers {
+		groups = append(groups, ss.conf.simplifyGroup(and))
+	}
+
+	// Drop the groups no version can satisfy, unless all of them are such
+	if nonEmpty := slices.DeleteFunc(slices.Clone(groups), func(and []specifier) bool {
+		return len(ss.conf.groupSpans(and)) == 0
+	}); len(nonEmpty) > 0 {
+		groups = nonEmpty
+	}
+
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(groups) && !merged; i++ {
+			for j := i + 1; j < len(groups) && !merged; j++ {
+				if and, ok := ss.conf.mergeGroups(groups[i], groups[j]); ok {
+					groups[i] = and
+					groups = slices.Delete(groups, j, j+1)
+					merged = true
+				}
+			}
+		}
+	}
+
+	// Drop the groups covered by the others
+	for i := 0; i < len(groups) && len(groups) > 1; {
+		others := slices.Delete(slices.Clone(groups), i, i+1)
+		if ss.conf.coveredBy(groups[i], others) {
+			groups = others
+		} else {
+			i++
+		}
+	}
+
+	return Specifiers{
+		specifiers: groups,
+		conf:       ss.conf,
+	}
+}
+
+// groupSpans returns the spans of the versions satisfying all the clauses.
+func (c conf) groupSpans(and []specifier) cutSpans {
+	spans := allVersions()
+	for _, s := range and {
+		spans = spans.intersect(s.spans(c.preRelease == PreReleaseAllow))
+	}
+	return spans
+}
+
+// simplifyGroup returns the clauses without the ones implied by the others.
+func (c conf) simplifyGroup(and []specifier) []specifier {
+	and = slices.Clone(and)
+	for i := 0; i < len(and) && len(and) > 1; {
+		rest := slices.Delete(slices.Clone(and), i, i+1)
+		if c.exact(and[i]) && c.groupSpans(rest).subsetOf(and[i].spans(c.preRelease == PreReleaseAllow)) &&
+			c.samePreReleases(rest, and) {
+			and = rest
+		} else {
+			i++
+		}
+	}
+	return and
+}
+
+// mergeGroups returns a single group of clauses satisfied by the versions satisfying either group, if any.
+func (c conf) mergeGroups(a, b []specifier) ([]specifier, bool) {
+	if !c.samePreReleases(a, b) || slices.ContainsFunc(a, c.inexact) || slices.ContainsFunc(b, c.inexact) {
+		return nil, false
+	}
+
+	union := c.groupSpans(a).union(c.groupSpans(b))
+	var candidates []specifier
+	for _, s := range slices.Concat(a, b) {
+		if union.subsetOf(s.spans(c.preRelease == PreReleaseAllow)) {
+			candidates = append(candidates, s)
+		}
+	}
+
+	and := c.simplifyGroup(candidates)
+	if len(and) == 0 || !c.groupSpans(and).equal(union) || !c.samePreReleases(and, a) {
+		return nil, false
+	}
+	return and, true
+}
+
+// coveredBy reports whether every version satisfying the group satisfies one of the others.
+func (c conf) coveredBy(and []specifier, others [][]specifier) bool {
+	var union cutSpans
+	for _, other := range others {
+		if slices.ContainsFunc(other, c.inexact) {
+			return false
+		}
+		// Under PreReleaseAuto, the pre-releases satisfying the group must be admitted by the others
+		if c.preRelease == PreReleaseAuto && admitsPreReleases(and) && !admitsPreReleases(other) {
+			return false
+		}
+		union = union.union(c.groupSpans(other))
+	}
+	return c.groupSpans(and).subsetOf(union)
+}
+
+// exact reports whether the spans of the clause are exactly the versions it matches.
+func (c conf) exact(s specifier) bool {
+	v := s.parsed
+	switch s.op {
+	case OpArbitrary:
+		return false
+	case OpEqual, OpNotEqual:
+		if !s.wildcard {
+			return true
+		}
+		// Prefix matching depends on the spelling, e.g. 1.0.0rc1 doesn't match ==1.0rc1.*
+		p := MustParse(strings.TrimSuffix(s.version, ".*"))
+		return p.epoch == 0 && p.pre.isNull() && p.post.isNull()
+	case OpGreaterThan:
+		// >1.0.post0 rejects the local versions of every release of 1.0, e.g. 1.0.post1+local, which no span leaves out
+		return v.IsFinal()
+	case OpLessThan:
+		return c.preRelease == PreReleaseAllow || v.IsPreRelease() || v.IsFinal()
+	}
+	return true
+}
+
+func (c conf) inexact(s specifier) bool {
+	return !c.exact(s)
+}
+
+// samePreReleases reports whether the groups admit the same pre-releases under the pre-release policy.
+func (c conf) samePreReleases(a, b []specifier) bool {
+	return c.preRelease != PreReleaseAuto || admitsPreReleases(a) == admitsPreReleases(b)
+}
+
+// admitsPreReleases reports whether any clause of the group allows pre-releases under PreReleaseAuto.
+func admitsPreReleases(and []specifier) bool {
+	return slices.ContainsFunc(and, func(s specifier) bool {
+		return s.preRelease
+	})
+}
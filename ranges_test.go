@@ -0,0 +1,470 @@
+package version
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rangeVersions are versions around the boundaries of rangeSpecifiers.
+var rangeVersions = func() []string {
+	var vs []string
+	for _, release := range []string{"0.9", "1.0", "1.0.1", "1.1", "1.9.9", "2.0", "2.0.0.1", "2.1", "3.0"} {
+		for _, suffix := range []string{"", ".dev0", ".dev1", "a1", "a1.dev0", "rc1", "rc1.post1", "rc2",
+			".post0.dev0", ".post0", ".post1", ".post1.dev0", "+local", "rc1+local", ".post1+local", ".dev0+local"} {
+			vs = append(vs, release+suffix, "1!"+release+suffix)
+		}
+	}
+	return vs
+}()
+
+// rangeSpecifiers are clauses of every operator.
+var rangeSpecifiers = func() []string {
+	var ss []string
+	for _, op := range []string{"==", "!=", "<", "<=", ">", ">=", "~="} {
+		for _, v := range []string{"1.0", "2.0", "1.0.1", "2.0rc1", "2.0.post1", "1!2.0", "1.0.dev1", "1.0a1"} {
+			ss = append(ss, op+v)
+		}
+	}
+	for _, v := range []string{"1.*", "1.0.*", "2.0rc1.*", "2.0.post1.*", "1!2.*"} {
+		ss = append(ss, "=="+v, "!="+v)
+	}
+	return append(ss, "===2.0", "===2.0rc1", "*", "==2.0+local", "!=2.0+local", ">1.0.post0")
+}()
+
+// widenedSpecifiers are the clauses of rangeSpecifiers whose spans include versions they don't match.
+var widenedSpecifiers = map[string]bool{
+	"<2.0.post1": true, ">2.0rc1": true, ">1.0.dev1": true, ">1.0a1": true, ">2.0.post1": true, ">1.0.post0": true,
+}
+
+func TestSpecifier_Spans(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	for _, allow := range []bool{false, true} {
+		for _, s := range rangeSpecifiers {
+			ss, err := NewSpecifiers(s, WithPreRelease(allow))
+			require.NoError(t, err)
+			spans := ss.spans()
+			for _, v := range versions {
+				if widenedSpecifiers[s] {
+					assert.True(t, !ss.Check(v) || spans.contains(v), "%s %s (pre-releases %v)", s, v, allow)
+				} else {
+					assert.Equal(t, ss.Check(v), spans.contains(v), "%s %s (pre-releases %v)", s, v, allow)
+				}
+			}
+		}
+	}
+}
+
+func TestSpecifiers_Spans(t *testing.T) {
+	if testing.Short() {
+		t.Skip("checking every pair of clauses")
+	}
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	for _, a := range rangeSpecifiers {
+		for _, b := range rangeSpecifiers {
+			if widenedSpecifiers[a] || widenedSpecifiers[b] || a == "*" || b == "*" {
+				continue
+			}
+			for _, s := range []string{a + "," + b, a + "||" + b} {
+				ss, err := NewSpecifiers(s)
+				require.NoError(t, err)
+				spans := ss.spans()
+				for _, v := range versions {
+					if ss.Check(v) != spans.contains(v) {
+						t.Fatalf("%s %s: got %v, want %v", s, v, spans.contains(v), ss.Check(v))
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSpecifiers_IsEmpty(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		opts       []SpecifierOption
+		want       bool
+	}{
+		{specifiers: ">=1.0, <2.0"},
+		{specifiers: "*"},
+		{specifiers: ">2.0, <1.0", want: true},
+		{specifiers: "==1.0, !=1.0", want: true},
+		{specifiers: "==1.0.*, !=1.0.*", want: true},
+		{specifiers: ">=2.0, <2.0", want: true},
+		{specifiers: ">=2.0, <=2.0"},
+		{specifiers: ">2.0, <=2.0", want: true},
+		// 2.0.post1 and 2.0+local are greater than 2.0 but don't match >2.0
+		{specifiers: ">2.0, <=2.0.post1", want: true},
+		{specifiers: ">2.0, <2.0.0.1"},
+		{specifiers: "~=1.4.5, <1.4.5", want: true},
+		{specifiers: "~=1.4.5, >=1.5", want: true},
+		// 2.0rc1 is excluded by <2.0 unless pre-releases are included
+		{specifiers: ">=2.0rc1, <2.0", want: true},
+		{specifiers: ">=2.0rc1, <2.0", opts: []SpecifierOption{WithPreRelease(true)}},
+		// != ignores the local version of the candidate like ==
+		{specifiers: "==1.0+local, !=1.0", want: true},
+		{specifiers: "==1.0+local, <=1.0"},
+		{specifiers: "==1.0+local, >=1.0.post0.dev0", want: true},
+		{specifiers: "===1.0, >1.0", want: true},
+		{specifiers: ">2.0, <1.0 || ==3.0"},
+		{specifiers: ">2.0, <1.0 || ==3.0, !=3.0", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ss.IsEmpty())
+		})
+	}
+	assert.True(t, Specifiers{}.IsEmpty())
+}
+
+func TestSpecifiers_Simplify(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		opts       []SpecifierOption
+		want       string
+	}{
+		{specifiers: ">=1.0,>=1.2,<2.0,!=3.0", want: ">=1.2,<2.0"},
+		{specifiers: ">=1.0, <2.0", want: ">=1.0,<2.0"},
+		{specifiers: ">=1.0,>=1.0", want: ">=1.0"},
+		{specifiers: "~=1.4.5, >=1.4, <2.0", want: "~=1.4.5"},
+		{specifiers: "==1.4.*, <2.0, !=1.5", want: "==1.4.*"},
+		{specifiers: ">=1.0,<2.0 || >=1.5,<3.0", want: ">=1.0,<3.0"},
+		{specifiers: ">=1.0,<2.0 || >=1.2,<1.8", want: ">=1.0,<2.0"},
+		{specifiers: "==1.5 || >=1.0,<2.0", want: ">=1.0,<2.0"},
+		{specifiers: ">=1.0,<2.0 || >=3.0", want: ">=1.0,<2.0||>=3.0"},
+		{specifiers: ">2.0,<1.0 || ==3.0", want: "==3.0"},
+		{specifiers: ">2.0,<1.0", want: ">2.0,<1.0"},
+		// >2.0rc1 doesn't match 2.0rc2.post1, so >=2.0rc2 doesn't imply it
+		{specifiers: ">2.0rc1, >=2.0rc2", want: ">2.0rc1,>=2.0rc2"},
+		{specifiers: ">=2.0rc2, >2.0", want: ">2.0"},
+		// Under PreReleaseAuto, >=2.0rc1 lets the group match pre-releases
+		{specifiers: ">=1.0, >=2.0rc1", opts: []SpecifierOption{WithPreReleasePolicy(PreReleaseAuto)}, want: ">=2.0rc1"},
+		{specifiers: ">=2.0rc1, >=2.0", opts: []SpecifierOption{WithPreReleasePolicy(PreReleaseAuto)},
+			want: ">=2.0rc1,>=2.0"},
+		{specifiers: ">=2.0rc1, >=2.0", want: ">=2.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers, tt.opts...)
+			require.NoError(t, err)
+			got := ss.Simplify()
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, ss.conf, got.conf)
+		})
+	}
+}
+
+func TestSpecifiers_Simplify_Equivalent(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	policies := []PreReleasePolicy{PreReleaseDefault, PreReleaseAllow, PreReleaseDeny, PreReleaseAuto}
+	clauses := slices.DeleteFunc(slices.Clone(rangeSpecifiers), func(s string) bool {
+		return s == "*"
+	})
+	for i, a := range clauses {
+		b, c := clauses[(i*7+3)%len(clauses)], clauses[(i*13+5)%len(clauses)]
+		for _, s := range []string{a + "," + b + "," + c, a + "," + b + "||" + c, a + "||" + b + "," + c, a + "||" + b + "||" + c} {
+			for _, policy := range policies {
+				ss, err := NewSpecifiers(s, WithPreReleasePolicy(policy))
+				require.NoError(t, err)
+				simplified := ss.Simplify()
+				for _, v := range versions {
+					if ss.Check(v) != simplified.Check(v) {
+						t.Fatalf("%s simplified to %s (policy %d) for %s: got %v, want %v",
+							s, simplified, policy, v, simplified.Check(v), ss.Check(v))
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	tests := []struct {
+		a, b      string
+		want      string
+		wantEmpty bool
+	}{
+		{a: ">=1.0,<2.0", b: ">=1.5", want: "<2.0,>=1.5"},
+		{a: ">=1.0,<2.0", b: "!=1.5.*", want: ">=1.0,<2.0,!=1.5.*"},
+		{a: "~=1.4.5", b: ">=1.0", want: "~=1.4.5"},
+		{a: ">=1.0,<2.0 || >=3.0", b: "<3.5", want: ">=1.0,<2.0||>=3.0,<3.5"},
+		{a: ">=1.0,<2.0 || >=3.0", b: "==1.5 || ==3.1", want: "==1.5||==3.1"},
+		{a: ">=2.0", b: "<1.0", want: ">=2.0,<1.0", wantEmpty: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			a, err := NewSpecifiers(tt.a)
+			require.NoError(t, err)
+			b, err := NewSpecifiers(tt.b)
+			require.NoError(t, err)
+
+			got, err := Intersect(a, b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Equal(t, tt.wantEmpty, got.IsEmpty())
+		})
+	}
+
+	a, err := NewSpecifiers(">=1.0", WithPreReleasePolicy(PreReleaseDeny))
+	require.NoError(t, err)
+	b, err := NewSpecifiers("<2.0")
+	require.NoError(t, err)
+	_, err = Intersect(a, b)
+	assert.ErrorIs(t, err, ErrIncompatibleSpecifiers)
+}
+
+func TestIntersect_Equivalent(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	for i, s := range rangeSpecifiers {
+		a, err := NewSpecifiers(s + "||" + rangeSpecifiers[(i*5+1)%len(rangeSpecifiers)])
+		require.NoError(t, err)
+		b, err := NewSpecifiers(rangeSpecifiers[(i*11+7)%len(rangeSpecifiers)])
+		require.NoError(t, err)
+
+		got, err := Intersect(a, b)
+		require.NoError(t, err)
+		for _, v := range versions {
+			if want := a.Check(v) && b.Check(v); got.Check(v) != want {
+				t.Fatalf("%s and %s is %s for %s: got %v, want %v", a, b, got, v, got.Check(v), want)
+			}
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{a: ">=1.0,<2.0", b: ">=1.5,<3.0", want: ">=1.0,<3.0"},
+		{a: ">=1.0,<2.0", b: ">=3.0", want: ">=1.0,<2.0||>=3.0"},
+		{a: ">=1.0,<2.0", b: "==1.5", want: ">=1.0,<2.0"},
+		{a: "==1.5", b: ">=1.0,<2.0", want: ">=1.0,<2.0"},
+		{a: "<1.0 || >=2.0", b: ">=1.0,<2.0 || ==0.5", want: "<1.0||>=2.0||>=1.0,<2.0"},
+		{a: ">2.0,<1.0", b: "==1.0", want: "==1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			a, err := NewSpecifiers(tt.a)
+			require.NoError(t, err)
+			b, err := NewSpecifiers(tt.b)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, Union(a, b).String())
+		})
+	}
+}
+
+func TestUnion_Equivalent(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	for i, s := range rangeSpecifiers {
+		a, err := NewSpecifiers(s)
+		require.NoError(t, err)
+		b, err := NewSpecifiers(rangeSpecifiers[(i*11+7)%len(rangeSpecifiers)])
+		require.NoError(t, err)
+
+		got := Union(a, b)
+		for _, v := range versions {
+			if want := a.Check(v) || b.Check(v); got.Check(v) != want {
+				t.Fatalf("%s or %s is %s for %s: got %v, want %v", a, b, got, v, got.Check(v), want)
+			}
+		}
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		bOpts []SpecifierOption
+		want  bool
+	}{
+		{a: ">=1.0,<2.0", b: ">=1.5", want: true},
+		{a: ">=1.0,<2.0", b: "<1.0", want: false},
+		{a: ">=1.0,<2.0", b: ">=2.0", want: false},
+		{a: "<=2.0", b: ">=2.0", want: true},
+		{a: "<2.0", b: ">=2.0rc1", want: false},
+		{a: "<2.0", b: ">=2.0rc1", bOpts: []SpecifierOption{WithPreRelease(true)}, want: false},
+		{a: ">=2.0rc1", b: "<2.0", bOpts: []SpecifierOption{WithPreRelease(true)}, want: true},
+		{a: "==1.0", b: "!=1.0", want: false},
+		{a: "==1.*", b: "<1.0 || >=3.0", want: false},
+		{a: "==1.*", b: "<1.5 || >=3.0", want: true},
+		{a: ">2.0", b: "<=2.0.post1", want: false},
+		{a: ">2.0,<1.0", b: ">=0.dev0", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			a, err := NewSpecifiers(tt.a)
+			require.NoError(t, err)
+			b, err := NewSpecifiers(tt.b, tt.bOpts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, Overlaps(a, b))
+			assert.Equal(t, tt.want, Overlaps(b, a))
+		})
+	}
+}
+
+func TestOverlaps_Sound(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	for _, s := range rangeSpecifiers {
+		a, err := NewSpecifiers(s)
+		require.NoError(t, err)
+		for _, o := range rangeSpecifiers {
+			b, err := NewSpecifiers(o)
+			require.NoError(t, err)
+			if Overlaps(a, b) {
+				continue
+			}
+			for _, v := range versions {
+				if a.Check(v) && b.Check(v) {
+					t.Fatalf("%s and %s don't overlap but both are satisfied by %s", a, b, v)
+				}
+			}
+		}
+	}
+}
+
+func TestSpecifiers_Complement(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		opts       []SpecifierOption
+		want       string
+		wantErr    bool
+	}{
+		{specifiers: ">=1.0,<2.0", want: "<1.0||>=2.0.dev0"},
+		{specifiers: ">=1.0,<2.0", opts: []SpecifierOption{WithPreRelease(true)}, want: "<1.0||>=2.0"},
+		{specifiers: "<1.0 || >=2.0", want: ">=1.0.dev0,<2.0"},
+		{specifiers: "!=1.0", want: "==1.0"},
+		{specifiers: "==1.*", want: "!=1.*"},
+		{specifiers: "<=2.0", want: ">=2.0.post0.dev0"},
+		{specifiers: ">2.0,<1.0", want: ">=0.dev0"},
+		{specifiers: "<2.0 || >=1.0", wantErr: true},
+		{specifiers: ">2.0", wantErr: true},
+		// >1.0.post0 rejects 1.0.post1+local
+		{specifiers: ">1.0.post0", wantErr: true},
+		{specifiers: "===1.0", wantErr: true},
+		{specifiers: ">=1.0", opts: []SpecifierOption{WithPreReleasePolicy(PreReleaseDeny)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers, tt.opts...)
+			require.NoError(t, err)
+			got, err := ss.Complement()
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnrepresentable)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestSpecifiers_Complement_Exact(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	for _, s := range rangeSpecifiers {
+		for _, opt := range []SpecifierOption{WithPreReleasePolicy(PreReleaseDefault), WithPreReleasePolicy(PreReleaseAllow)} {
+			ss, err := NewSpecifiers(s, opt)
+			require.NoError(t, err)
+			got, err := ss.Complement()
+			if errors.Is(err, ErrUnrepresentable) {
+				continue
+			}
+			require.NoError(t, err, s)
+			for _, v := range versions {
+				if got.Check(v) == ss.Check(v) {
+					t.Fatalf("%s is the complement of %s but both are %v for %s", got, ss, ss.Check(v), v)
+				}
+			}
+		}
+	}
+}
+
+func TestSpecifiers_SubsetOf(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		aOpts  []SpecifierOption
+		bOpts  []SpecifierOption
+		want   bool
+		wantBA bool
+	}{
+		{a: ">=1.2,<1.5", b: ">=1.0,<2.0", want: true},
+		// ==1.* includes 1.0.dev0
+		{a: "==1.*", b: ">=1.0"},
+		{a: "==1.1.*", b: ">=1.0", want: true},
+		{a: "~=1.4.5", b: "==1.4.*", want: true},
+		{a: ">=1.0,<2.0", b: ">=1.0,<2.0", want: true, wantBA: true},
+		{a: "<2.0,>=1.0", b: ">=1.0.0,<2", want: true, wantBA: true},
+		{a: ">=1.0,<2.0 || >=3.0", b: ">=1.0", want: true},
+		// <2.0 doesn't match 2.0rc1
+		{a: ">=1.0", b: ">=1.0,<2.0 || >=2.0", wantBA: true},
+		{a: ">=1.0,<2.0", b: ">=1.5"},
+		{a: "==1.0+local", b: "==1.0", want: true},
+		// <2.0 doesn't match 2.0rc1 while <=2.0 does
+		{a: "<2.0", b: "<=2.0", want: true},
+		{a: "<2.0", b: "<2.0.dev0", want: true, wantBA: true},
+		{a: ">2.0rc1", b: ">2.0rc1", want: true, wantBA: true},
+		{a: ">2.0", b: ">2.0rc1", want: false, wantBA: false},
+		{a: "==1.0", b: "===1.0", wantBA: true},
+		{a: ">2.0,<1.0", b: "==3.0", want: true},
+		// Pre-release policies
+		{a: ">=1.0", b: ">=1.0", bOpts: []SpecifierOption{WithPreReleasePolicy(PreReleaseDeny)}, wantBA: true},
+		{a: ">=1.0", b: ">=1.0", bOpts: []SpecifierOption{WithPreReleasePolicy(PreReleaseAuto)}, wantBA: true},
+		{a: ">=2.0a1", b: ">=1.0 || >=2.0a1", bOpts: []SpecifierOption{WithPreReleasePolicy(PreReleaseAuto)},
+			want: true},
+		{a: ">=1.0", b: "<3.0", aOpts: []SpecifierOption{WithPreReleasePolicy(PreReleaseDeny)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			a, err := NewSpecifiers(tt.a, tt.aOpts...)
+			require.NoError(t, err)
+			b, err := NewSpecifiers(tt.b, tt.bOpts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, a.SubsetOf(b))
+			assert.Equal(t, tt.wantBA, b.SubsetOf(a))
+		})
+	}
+}
+
+func TestSpecifiers_SubsetOf_Sound(t *testing.T) {
+	versions, err := ParseAll(rangeVersions)
+	require.NoError(t, err)
+
+	policies := []PreReleasePolicy{PreReleaseDefault, PreReleaseAllow, PreReleaseDeny, PreReleaseAuto}
+	for i, s := range rangeSpecifiers {
+		for j, policy := range policies {
+			a, err := NewSpecifiers(s, WithPreReleasePolicy(policy))
+			require.NoError(t, err)
+			for k := 0; k < 8; k++ {
+				other := rangeSpecifiers[(i*(k+3)+k)%len(rangeSpecifiers)]
+				b, err := NewSpecifiers(other+"||"+s, WithPreReleasePolicy(policies[(j+k)%len(policies)]))
+				require.NoError(t, err)
+				if !a.SubsetOf(b) {
+					continue
+				}
+				for _, v := range versions {
+					if a.Check(v) && !b.Check(v) {
+						t.Fatalf("%s (%d) is not a subset of %s (%d): %s", a, policy, b, b.conf.preRelease, v)
+					}
+				}
+			}
+		}
+	}
+}
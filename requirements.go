@@ -0,0 +1,187 @@
+package version
+
+import (
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	// https://peps.python.org/pep-0508/#names
+	projectNameRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`(?i)^([a-z0-9]|[a-z0-9][a-z0-9._-]*[a-z0-9])$`)
+	})
+
+	// The name, the extras and the rest of a PEP 508 dependency specification
+	requirementRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`^\s*([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)\s*(\[[^\]]*\])?\s*(.*?)\s*$`)
+	})
+
+	// https://peps.python.org/pep-0503/#normalized-names
+	projectNameSeparatorRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`[-_.]+`)
+	})
+)
+
+// Requirement is a PEP 508 dependency specification, such as a line of a requirements or constraints file,
+// e.g. "requests[socks]>=2.0,<3; python_version >= '3.8'".
+type Requirement struct {
+	// Name is the project name, e.g. "requests".
+	Name string
+	// Extras are the optional features of the project, e.g. "socks".
+	Extras []string
+	// Specifiers are the allowed versions of the project. The zero Specifiers allows every version.
+	Specifiers Specifiers
+	// URL is the location of the project instead of Specifiers, e.g. "https://example.com/requests.zip".
+	URL string
+	// Marker is the environment marker which must hold for the requirement to apply, e.g. "os_name == 'nt'".
+	// It isn't evaluated.
+	Marker string
+}
+
+// ParseRequirement parses a PEP 508 dependency specification, e.g. "requests [socks] (>=2.0, <3)".
+// It returns ErrInvalidRequirement if it is malformed, or the error of NewSpecifiers for the specifiers.
+func ParseRequirement(s string, opts ...SpecifierOption) (Requirement, error) {
+	m := requirementRegexp().FindStringSubmatch(s)
+	if m == nil || strings.HasPrefix(m[3], "[") {
+		return Requirement{}, xerrors.Errorf("%q: %w", s, ErrInvalidRequirement)
+	}
+	r := Requirement{Name: m[1]}
+	if m[2] != "" {
+		for _, extra := range strings.Split(m[2][1:len(m[2])-1], ",") {
+			extra = strings.TrimSpace(extra)
+			if !projectNameRegexp().MatchString(extra) {
+				return Requirement{}, xerrors.Errorf("%q: extra %q: %w", s, extra, ErrInvalidRequirement)
+			}
+			r.Extras = append(r.Extras, extra)
+		}
+	}
+
+	rest := m[3]
+	if url, ok := strings.CutPrefix(rest, "@"); ok {
+		// A marker must be preceded by whitespace as a URL may contain ';'
+		r.URL, r.Marker, _ = strings.Cut(strings.TrimSpace(url), " ;")
+		r.URL = strings.TrimSpace(r.URL)
+		if r.URL == "" {
+			return Requirement{}, xerrors.Errorf("%q: empty URL: %w", s, ErrInvalidRequirement)
+		}
+	} else {
+		var spec string
+		spec, r.Marker, _ = strings.Cut(rest, ";")
+		spec = strings.TrimSpace(spec)
+		if inner, ok := strings.CutPrefix(spec, "("); ok {
+			if spec, ok = strings.CutSuffix(inner, ")"); !ok {
+				return Requirement{}, xerrors.Errorf("%q: unclosed parenthesis: %w", s, ErrInvalidRequirement)
+			}
+		}
+		if strings.Contains(spec, "||") {
+			return Requirement{}, xerrors.Errorf("%q: OR groups: %w", s, ErrInvalidRequirement)
+		}
+		if strings.TrimSpace(spec) != "" {
+			ss, err := NewSpecifiers(spec, opts...)
+			if err != nil {
+				return Requirement{}, xerrors.Errorf("%q: %w", s, err)
+			}
+			r.Specifiers = ss
+		}
+	}
+	r.Marker = strings.TrimSpace(r.Marker)
+	return r, nil
+}
+
+// Pin returns the requirement pinning the project to the version, e.g. "requests==2.31.0" as pip freeze writes.
+func Pin(name string, v Version) (Requirement, error) {
+	ss, err := NewSpecifiers(string(OpEqual) + v.String())
+	if err != nil {
+		return Requirement{}, xerrors.Errorf("pinning %s: %w", name, err)
+	}
+	return Requirement{Name: name, Specifiers: ss}, nil
+}
+
+// String returns the requirement with the sorted extras and the canonical form of the specifiers,
+// e.g. "requests[socks]<3,>=2.0; python_version >= '3.8'".
+func (r Requirement) String() string {
+	var sb strings.Builder
+	sb.WriteString(r.Name)
+	if len(r.Extras) > 0 {
+		extras := slices.Clone(r.Extras)
+		slices.Sort(extras)
+		sb.WriteString("[" + strings.Join(slices.Compact(extras), ",") + "]")
+	}
+	if r.URL != "" {
+		sb.WriteString(" @ " + r.URL)
+	} else {
+		sb.WriteString(r.Specifiers.Canonical())
+	}
+	if r.Marker != "" {
+		if r.URL != "" {
+			sb.WriteString(" ")
+		}
+		sb.WriteString("; " + r.Marker)
+	}
+	return sb.String()
+}
+
+// normalizedName returns the name compared by package indexes, e.g. "zope-interface" for "Zope.Interface".
+func (r Requirement) normalizedName() string {
+	return strings.ToLower(projectNameSeparatorRegexp().ReplaceAllString(r.Name, "-"))
+}
+
+// WriteRequirements writes the requirements to w in the requirements.txt and constraints.txt format, one per line,
+// so that files written from the same requirements are identical. The lines are sorted by the normalized project
+// names and the markers, and the requirements of the same project and marker are combined into the line of the first
+// one, with the specifiers combined with And and the extras joined. As the format has no OR, it returns
+// ErrUnrepresentable for specifiers with several OR groups, and ErrInvalidRequirement for a name which isn't a valid
+// project name or requirements of a project with different URLs. The specifier options aren't written.
+func WriteRequirements(w io.Writer, reqs []Requirement) error {
+	var merged []Requirement
+	for _, r := range reqs {
+		if !projectNameRegexp().MatchString(r.Name) {
+			return xerrors.Errorf("project name %q: %w", r.Name, ErrInvalidRequirement)
+		} else if len(r.Specifiers.specifiers) > 1 {
+			return xerrors.Errorf("%s: %w", r, ErrUnrepresentable)
+		}
+
+		i := slices.IndexFunc(merged, func(m Requirement) bool {
+			return m.normalizedName() == r.normalizedName() && m.Marker == r.Marker
+		})
+		if i < 0 {
+			merged = append(merged, r)
+			continue
+		}
+
+		m := &merged[i]
+		if m.URL != r.URL && (m.URL != "" || len(m.Specifiers.specifiers) > 0) &&
+			(r.URL != "" || len(r.Specifiers.specifiers) > 0) {
+			return xerrors.Errorf("%s and %s: %w", m, r, ErrInvalidRequirement)
+		}
+		m.Extras = append(slices.Clip(m.Extras), r.Extras...)
+		switch {
+		case r.URL != "":
+			m.URL = r.URL
+		case len(m.Specifiers.specifiers) == 0:
+			m.Specifiers = r.Specifiers
+		case len(r.Specifiers.specifiers) > 0:
+			m.Specifiers = m.Specifiers.And(r.Specifiers)
+		}
+	}
+	slices.SortStableFunc(merged, func(a, b Requirement) int {
+		if c := strings.Compare(a.normalizedName(), b.normalizedName()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Marker, b.Marker)
+	})
+
+	var sb strings.Builder
+	for _, r := range merged {
+		sb.WriteString(r.String() + "\n")
+	}
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return xerrors.Errorf("writing requirements: %w", err)
+	}
+	return nil
+}
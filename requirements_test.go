@@ -0,0 +1,154 @@
+package version
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteRequirements(t *testing.T) {
+	specifiers := func(s string) Specifiers {
+		ss, err := NewSpecifiers(s)
+		require.NoError(t, err)
+		return ss
+	}
+	pin := func(name, v string) Requirement {
+		r, err := Pin(name, MustParse(v))
+		require.NoError(t, err)
+		return r
+	}
+	tests := []struct {
+		name    string
+		reqs    []Requirement
+		want    string
+		wantErr error
+	}{
+		{
+			name: "sorted",
+			reqs: []Requirement{
+				{Name: "requests", Specifiers: specifiers(">= 2.0, <3")},
+				pin("Django", "4.2"),
+				{Name: "zope.interface"},
+				pin("attrs", "v23.1.0+local"),
+			},
+			want: "attrs==23.1.0+local\nDjango==4.2\nrequests<3,>=2.0\nzope.interface\n",
+		},
+		{
+			name: "normalized names",
+			reqs: []Requirement{
+				{Name: "Zope.Interface", Specifiers: specifiers(">=5")},
+				{Name: "zope-b"},
+				{Name: "zope_interface", Specifiers: specifiers("<6")},
+				{Name: "ZOPE--INTERFACE", Specifiers: specifiers(">= 5")},
+			},
+			want: "zope-b\nZope.Interface<6,>=5\n",
+		},
+		{
+			name: "combined with every version",
+			reqs: []Requirement{{Name: "six"}, {Name: "six", Specifiers: specifiers("==1.*")}, {Name: "six"}},
+			want: "six==1.*\n",
+		},
+		{
+			name: "markers and extras",
+			reqs: []Requirement{
+				{Name: "pywin32", Specifiers: specifiers(">=300"), Marker: "os_name == 'nt'"},
+				{Name: "requests", Extras: []string{"socks"}},
+				{Name: "pywin32", Marker: "os_name == 'nt'", Specifiers: specifiers("<310")},
+				{Name: "requests", Extras: []string{"security", "socks"}, Specifiers: specifiers(">=2")},
+				{Name: "pywin32", Specifiers: specifiers("==306"), Marker: "os_name == 'cygwin'"},
+				{Name: "mylib", URL: "https://example.com/mylib.zip", Marker: "python_version < '3.9'"},
+				{Name: "mylib", Marker: "python_version < '3.9'"},
+			},
+			want: "mylib @ https://example.com/mylib.zip ; python_version < '3.9'\n" +
+				"pywin32==306; os_name == 'cygwin'\npywin32<310,>=300; os_name == 'nt'\nrequests[security,socks]>=2\n",
+		},
+		{
+			name: "different URLs",
+			reqs: []Requirement{
+				{Name: "mylib", URL: "https://example.com/mylib.zip"},
+				{Name: "mylib", Specifiers: specifiers(">=1.0")},
+			},
+			wantErr: ErrInvalidRequirement,
+		},
+		{name: "empty"},
+		{
+			name:    "or",
+			reqs:    []Requirement{{Name: "six", Specifiers: specifiers("<1 || >2")}},
+			wantErr: ErrUnrepresentable,
+		},
+		{
+			name:    "invalid name",
+			reqs:    []Requirement{{Name: "-six"}},
+			wantErr: ErrInvalidRequirement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			err := WriteRequirements(&sb, tt.reqs)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sb.String())
+		})
+	}
+}
+
+func TestParseRequirement(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Requirement
+		wantStr string
+		wantErr error
+	}{
+		{input: "requests", want: Requirement{Name: "requests"}, wantStr: "requests"},
+		{
+			input:   "requests [socks, security] (>= 2.0, <3) ; python_version >= '3.8'",
+			want:    Requirement{Name: "requests", Extras: []string{"socks", "security"}, Marker: "python_version >= '3.8'"},
+			wantStr: "requests[security,socks]<3,>=2.0; python_version >= '3.8'",
+		},
+		{input: "zope.interface>=5", want: Requirement{Name: "zope.interface"}, wantStr: "zope.interface>=5"},
+		{input: "six ==1.*;python_version<'3'", want: Requirement{Name: "six", Marker: "python_version<'3'"}, wantStr: "six==1.*; python_version<'3'"},
+		{
+			input:   "mylib @ https://example.com/mylib.zip;v=1 ; os_name == 'nt'",
+			want:    Requirement{Name: "mylib", URL: "https://example.com/mylib.zip;v=1", Marker: "os_name == 'nt'"},
+			wantStr: "mylib @ https://example.com/mylib.zip;v=1 ; os_name == 'nt'",
+		},
+		{input: "name[]", wantErr: ErrInvalidRequirement},
+		{input: "-requests", wantErr: ErrInvalidRequirement},
+		{input: "requests[socks", wantErr: ErrInvalidRequirement},
+		{input: "requests (>=2.0", wantErr: ErrInvalidRequirement},
+		{input: "requests @ ", wantErr: ErrInvalidRequirement},
+		{input: "requests <1 || >2", wantErr: ErrInvalidRequirement},
+		{input: "requests >>2", wantErr: ErrInvalidSpecifier},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRequirement(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Name, got.Name)
+			assert.Equal(t, tt.want.Extras, got.Extras)
+			assert.Equal(t, tt.want.URL, got.URL)
+			assert.Equal(t, tt.want.Marker, got.Marker)
+			assert.Equal(t, tt.wantStr, got.String())
+		})
+	}
+}
+
+func TestPin(t *testing.T) {
+	r, err := Pin("requests", MustParse("2.31.0"))
+	require.NoError(t, err)
+	assert.Equal(t, "requests==2.31.0", r.String())
+	assert.True(t, r.Specifiers.Check(MustParse("2.31")))
+
+	_, err = Pin("requests", Inf)
+	require.ErrorIs(t, err, ErrInvalidSpecifier)
+}
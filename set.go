@@ -0,0 +1,86 @@
+package version
+
+// VersionSet is a set of versions under PEP 440 equality, e.g. 1.0 and 1.0.0 are the same element, hashed by Key.
+// The zero VersionSet is empty and ready to use.
+type VersionSet struct {
+	versions map[string]Version
+}
+
+// NewVersionSet returns a set of the versions.
+func NewVersionSet(versions ...Version) VersionSet {
+	var s VersionSet
+	s.Add(versions...)
+	return s
+}
+
+// Add adds the versions to the set. A version equal to one in the set is ignored, so the set keeps the spelling
+// added first.
+func (s *VersionSet) Add(versions ...Version) {
+	if s.versions == nil {
+		s.versions = make(map[string]Version, len(versions))
+	}
+	for _, v := range versions {
+		key := v.Key()
+		if _, ok := s.versions[key]; !ok {
+			s.versions[key] = v
+		}
+	}
+}
+
+// Contains reports whether the set has a version equal to v.
+func (s VersionSet) Contains(v Version) bool {
+	_, ok := s.versions[v.Key()]
+	return ok
+}
+
+// Len returns the number of versions in the set.
+func (s VersionSet) Len() int {
+	return len(s.versions)
+}
+
+// Versions returns the versions in the set in ascending order.
+func (s VersionSet) Versions() Collection {
+	c := make(Collection, 0, len(s.versions))
+	for _, v := range s.versions {
+		c = append(c, v)
+	}
+	c.Sort()
+	return c
+}
+
+// Union returns the versions in either set, with the spellings of s for the ones in both.
+func (s VersionSet) Union(other VersionSet) VersionSet {
+	u := VersionSet{versions: make(map[string]Version, len(s.versions)+len(other.versions))}
+	for key, v := range s.versions {
+		u.versions[key] = v
+	}
+	for key, v := range other.versions {
+		if _, ok := u.versions[key]; !ok {
+			u.versions[key] = v
+		}
+	}
+	return u
+}
+
+// Intersect returns the versions in both sets, with the spellings of s.
+func (s VersionSet) Intersect(other VersionSet) VersionSet {
+	i := VersionSet{versions: make(map[string]Version)}
+	for key, v := range s.versions {
+		if _, ok := other.versions[key]; ok {
+			i.versions[key] = v
+		}
+	}
+	return i
+}
+
+// Difference returns the versions in s but not in other, e.g. the versions installed in one environment but not in
+// another.
+func (s VersionSet) Difference(other VersionSet) VersionSet {
+	d := VersionSet{versions: make(map[string]Version)}
+	for key, v := range s.versions {
+		if _, ok := other.versions[key]; !ok {
+			d.versions[key] = v
+		}
+	}
+	return d
+}
@@ -0,0 +1,47 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+func TestVersionSet(t *testing.T) {
+	newSet := func(vs ...string) version.VersionSet {
+		versions, err := version.ParseAll(vs)
+		require.NoError(t, err)
+		return version.NewVersionSet(versions...)
+	}
+	originals := func(s version.VersionSet) []string {
+		var got []string
+		for _, v := range s.Versions() {
+			got = append(got, v.Original())
+		}
+		return got
+	}
+
+	a := newSet("1.0", "1.1", "2.0rc1", "1.0.0", "2.0+local")
+	b := newSet("1.1.0", "v2.0rc1", "3.0", "2.0")
+
+	assert.Equal(t, 4, a.Len())
+	assert.Equal(t, []string{"1.0", "1.1", "2.0rc1", "2.0+local"}, originals(a))
+	assert.True(t, a.Contains(version.MustParse("1.0.0.0")))
+	assert.False(t, a.Contains(version.MustParse("2.0")))
+
+	assert.Equal(t, []string{"1.0", "1.1", "2.0rc1", "2.0", "2.0+local", "3.0"}, originals(a.Union(b)))
+	assert.Equal(t, []string{"1.1", "2.0rc1"}, originals(a.Intersect(b)))
+	assert.Equal(t, []string{"1.1.0", "v2.0rc1"}, originals(b.Intersect(a)))
+	assert.Equal(t, []string{"1.0", "2.0+local"}, originals(a.Difference(b)))
+	assert.Equal(t, []string{"2.0", "3.0"}, originals(b.Difference(a)))
+
+	var zero version.VersionSet
+	assert.Equal(t, 0, zero.Len())
+	assert.False(t, zero.Contains(version.MustParse("1.0")))
+	assert.Equal(t, 4, zero.Union(a).Len())
+	assert.Equal(t, 0, a.Intersect(zero).Len())
+	zero.Add(version.MustParse("1.0"), version.MustParse("1.0.0"))
+	assert.Equal(t, []string{"1.0"}, originals(zero))
+}
@@ -1,52 +1,87 @@
 package version
 
 import (
+	"errors"
 	"fmt"
-	"reflect"
 	"regexp"
-	"strconv"
+	"slices"
 	"strings"
+	"sync"
+	"unicode"
 
 	"golang.org/x/xerrors"
 )
 
-var (
-	specifierOperators = map[string]operatorFunc{
-		"":    specifierEqual, // not defined in PEP 440
-		"=":   specifierEqual, // not defined in PEP 440
-		"==":  specifierEqual,
-		"!=":  specifierNotEqual,
-		">":   specifierGreaterThan,
-		"<":   specifierLessThan,
-		">=":  specifierGreaterThanEqual,
-		"<=":  specifierLessThanEqual,
-		"~=":  specifierCompatible,
-		"===": specifierArbitrary,
-	}
-
-	specifierRegexp       *regexp.Regexp
-	validConstraintRegexp *regexp.Regexp
-	prefixRegexp          *regexp.Regexp
+// Operator is the comparison operator of a specifier clause.
+type Operator string
+
+// The operators defined in PEP 440
+const (
+	OpCompatible       Operator = "~="
+	OpEqual            Operator = "=="
+	OpNotEqual         Operator = "!="
+	OpLessThanEqual    Operator = "<="
+	OpGreaterThanEqual Operator = ">="
+	OpLessThan         Operator = "<"
+	OpGreaterThan      Operator = ">"
+	OpArbitrary        Operator = "==="
 )
 
-func init() {
-	ops := make([]string, 0, len(specifierOperators))
-	for k := range specifierOperators {
-		ops = append(ops, regexp.QuoteMeta(k))
+var (
+	specifierOperators = map[Operator]operatorFunc{
+		"":                 specifierEqual, // not defined in PEP 440
+		"=":                specifierEqual, // not defined in PEP 440
+		OpEqual:            specifierEqual,
+		OpNotEqual:         specifierNotEqual,
+		OpGreaterThan:      specifierGreaterThan,
+		OpLessThan:         specifierLessThan,
+		OpGreaterThanEqual: specifierGreaterThanEqual,
+		OpLessThanEqual:    specifierLessThanEqual,
+		OpCompatible:       specifierCompatible,
+		OpArbitrary:        specifierArbitrary,
 	}
 
-	specifierRegexp = regexp.MustCompile(fmt.Sprintf(
-		`(?i)(?P<operator>(%s))\s*(?P<version>%s(\.\*)?)`,
-		strings.Join(ops, "|"), regex))
+	// Operators commonly mistyped or borrowed from other ecosystems
+	operatorTypos = map[string]string{
+		"=>": ">=",
+		"=<": "<=",
+		"<>": "!=",
+		"=!": "!=",
+		"~":  "~=",
+		"~>": "~=",
+		"=~": "~=",
+	}
 
-	validConstraintRegexp = regexp.MustCompile(fmt.Sprintf(
-		`^\s*(\s*(%s)\s*(%s(\.\*)?)\s*\,?)*\s*$`,
-		strings.Join(ops, "|"), regex))
+	// The regular expressions are compiled on first use like the version ones.
+	specifierRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(fmt.Sprintf(
+			`(?i)(?P<operator>(%s))\s*(?P<version>%s(\.\*)?)`,
+			operatorPattern(), regex))
+	})
+
+	validConstraintRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(fmt.Sprintf(
+			`^\s*(\s*(%s)\s*(%s(\.\*)?)\s*\,?)*\s*$`,
+			operatorPattern(), regex))
+	})
+
+	constraintPrefixRegexp = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(fmt.Sprintf(
+			`^\s*(\s*(%s)\s*(%s(\.\*)?)\s*\,?)*`,
+			operatorPattern(), regex))
+	})
+)
 
-	prefixRegexp = regexp.MustCompile(`^([0-9]+)((?:a|b|c|rc)[0-9]+)$`)
+// operatorPattern returns the alternation of the specifier operators for the regular expressions.
+func operatorPattern() string {
+	ops := make([]string, 0, len(specifierOperators))
+	for k := range specifierOperators {
+		ops = append(ops, regexp.QuoteMeta(string(k)))
+	}
+	return strings.Join(ops, "|")
 }
 
-type operatorFunc func(v Version, c string) bool
+type operatorFunc func(v Version, s specifier) bool
 
 type Specifiers struct {
 	specifiers [][]specifier
@@ -54,9 +89,30 @@ type Specifiers struct {
 }
 
 type specifier struct {
+	op       Operator // the operator, with the ones not defined in PEP 440 normalized
+	bare     bool     // whether the operator is omitted, e.g. "2.0"
 	version  string
 	operator operatorFunc
 	original string
+
+	// The following are computed once by newSpecifier so that checking a version doesn't parse the spec.
+	parsed     Version  // the spec version, unset for wildcards and ===
+	wildcard   bool     // whether the spec version ends with .*
+	prefix     []string // the split prefix to match for wildcards and ~=
+	preRelease bool     // whether the clause allows pre-releases under PreReleaseAuto
+}
+
+// Specifier is a single clause of Specifiers, such as ">=1.0".
+type Specifier struct {
+	// Operator is the operator of the clause. A bare version (e.g. "2.0") or "=" is reported as OpEqual.
+	Operator Operator
+	// Version is the version as written, including a trailing ".*" for prefix matching.
+	Version string
+}
+
+// String returns the clause without spaces, e.g. ">=1.0".
+func (s Specifier) String() string {
+	return string(s.Operator) + s.Version
 }
 
 // NewSpecifiers parses a given specifier and returns a new instance of Specifiers
@@ -68,27 +124,72 @@ func NewSpecifiers(v string, opts ...SpecifierOption) (Specifiers, error) {
 		o.apply(c)
 	}
 
+	if c.maxLength > 0 && len(v) > c.maxLength {
+		return Specifiers{}, xerrors.Errorf("specifiers of %d bytes exceed the maximum of %d: %w",
+			len(v), c.maxLength, ErrInputTooLong)
+	}
+
 	var sss [][]specifier
+	var offset, clauses int
 	for _, vv := range strings.Split(v, "||") {
+		segmentOffset := offset
+		offset += len(vv) + len("||")
+
 		if strings.TrimSpace(vv) == "*" {
 			vv = ">=0.0.0"
 		}
 
 		// Validate the segment
-		if !validConstraintRegexp.MatchString(vv) {
-			return Specifiers{}, xerrors.Errorf("improper constraint: %s", vv)
+		if !validConstraintRegexp().MatchString(vv) {
+			err := newParseError(vv, constraintPrefixRegexp(), ErrInvalidSpecifier)
+			err.Input = v
+			err.Offset += segmentOffset
+			err.Suggestion = suggestInput(v, segmentOffset, segmentOffset+len(vv), err.Offset)
+			return Specifiers{}, err
+		}
+
+		locs := specifierRegexp().FindAllStringIndex(vv, -1)
+		if locs == nil {
+			trimmed := strings.TrimLeftFunc(vv, unicode.IsSpace)
+			start := len(vv) - len(trimmed)
+			locs = append(locs, []int{start, start + len(strings.TrimSpace(trimmed))})
 		}
 
-		ss := specifierRegexp.FindAllString(vv, -1)
-		if ss == nil {
-			ss = append(ss, strings.TrimSpace(vv))
+		if c.maxClauses > 0 && clauses+len(locs) > c.maxClauses {
+			// Point at the first clause over the limit
+			loc := locs[c.maxClauses-clauses]
+			return Specifiers{}, &ParseError{
+				Input:  v,
+				Offset: segmentOffset + loc[0],
+				Token:  vv[loc[0]:loc[1]],
+				Err:    ErrTooManyClauses,
+			}
 		}
+		clauses += len(locs)
 
 		var specs []specifier
-		for _, single := range ss {
+		for _, loc := range locs {
+			single := vv[loc[0]:loc[1]]
 			s, err := newSpecifier(single)
 			if err != nil {
-				return Specifiers{}, err
+				return Specifiers{}, &ParseError{
+					Input:  v,
+					Offset: segmentOffset + loc[0],
+					Token:  single,
+					Err:    err,
+				}
+			}
+			if c.requireOperator && s.bare {
+				// The match of a bare version includes the spaces before it
+				token := strings.TrimLeftFunc(single, unicode.IsSpace)
+				offset := segmentOffset + loc[0] + len(single) - len(token)
+				return Specifiers{}, &ParseError{
+					Input:      v,
+					Offset:     offset,
+					Token:      token,
+					Err:        ErrMissingOperator,
+					Suggestion: v[:offset] + string(OpEqual) + v[offset:],
+				}
 			}
 			specs = append(specs, s)
 		}
@@ -102,29 +203,205 @@ func NewSpecifiers(v string, opts ...SpecifierOption) (Specifiers, error) {
 
 }
 
+// ValidateSpecifiers checks the given specifiers like NewSpecifiers without constructing them, but reports every
+// invalid clause rather than only the first one. The errors are *ParseError locating each clause in s, except for
+// ErrInputTooLong. It returns nil if NewSpecifiers would accept s.
+func ValidateSpecifiers(s string, opts ...SpecifierOption) []error {
+	c := new(conf)
+
+	// Apply options
+	for _, o := range opts {
+		o.apply(c)
+	}
+
+	if c.maxLength > 0 && len(s) > c.maxLength {
+		return []error{xerrors.Errorf("specifiers of %d bytes exceed the maximum of %d: %w",
+			len(s), c.maxLength, ErrInputTooLong)}
+	}
+
+	var errs []error
+	var offset, clauses int
+	for _, segment := range strings.Split(s, "||") {
+		segmentOffset := offset
+		offset += len(segment) + len("||")
+
+		if strings.TrimSpace(segment) == "*" {
+			clauses++
+			continue
+		}
+
+		// Clauses are validated one comma-separated chunk at a time, so that an invalid one doesn't hide the others.
+		chunks := strings.Split(segment, ",")
+		chunkOffset := segmentOffset
+		for i, chunk := range chunks {
+			start := chunkOffset
+			chunkOffset += len(chunk) + len(",")
+
+			// Trim only what \s matches in the constraint regexps, so that the result agrees with NewSpecifiers
+			token := strings.Trim(chunk, " \t\n\f\r")
+			start += strings.Index(chunk, token)
+			invalid := &ParseError{Input: s, Offset: start, Token: token, Err: ErrInvalidSpecifier}
+
+			// Only a trailing comma may be followed by nothing
+			if token == "" {
+				if i == 0 || i < len(chunks)-1 {
+					errs = append(errs, invalid)
+				}
+				continue
+			}
+
+			locs := specifierRegexp().FindAllStringIndex(token, -1)
+			if locs == nil || !validConstraintRegexp().MatchString(token) {
+				invalid.Suggestion = suggestInput(s, start, start+len(token), start)
+				errs = append(errs, invalid)
+				continue
+			}
+
+			for _, loc := range locs {
+				clauses++
+				single := strings.TrimLeftFunc(token[loc[0]:loc[1]], unicode.IsSpace)
+				clauseOffset := start + loc[1] - len(single)
+
+				sp, err := newSpecifier(single)
+				switch {
+				case err != nil:
+					errs = append(errs, &ParseError{Input: s, Offset: clauseOffset, Token: single, Err: err})
+				case c.requireOperator && sp.bare:
+					errs = append(errs, &ParseError{
+						Input:      s,
+						Offset:     clauseOffset,
+						Token:      single,
+						Err:        ErrMissingOperator,
+						Suggestion: s[:clauseOffset] + string(OpEqual) + s[clauseOffset:],
+					})
+				case c.maxClauses > 0 && clauses == c.maxClauses+1:
+					errs = append(errs, &ParseError{Input: s, Offset: clauseOffset, Token: single, Err: ErrTooManyClauses})
+				}
+			}
+		}
+	}
+	return errs
+}
+
 func newSpecifier(s string) (specifier, error) {
-	m := specifierRegexp.FindStringSubmatch(s)
+	m := specifierRegexp().FindStringSubmatch(s)
 	if m == nil {
-		return specifier{}, xerrors.Errorf("improper specifier: %s", s)
+		return specifier{}, ErrInvalidSpecifier
 	}
 
-	operator := m[specifierRegexp.SubexpIndex("operator")]
-	version := m[specifierRegexp.SubexpIndex("version")]
+	operator := Operator(m[specifierRegexp().SubexpIndex("operator")])
+	version := m[specifierRegexp().SubexpIndex("version")]
 
-	if operator != "===" {
+	if operator != OpArbitrary {
 		if err := validate(operator, version); err != nil {
 			return specifier{}, err
 		}
 	}
 
-	return specifier{
+	spec := specifier{
+		op:       normalizeOperator(operator),
+		bare:     operator == "",
 		version:  version,
 		operator: specifierOperators[operator],
 		original: s,
-	}, nil
+	}
+
+	switch {
+	case operator == OpArbitrary:
+	case strings.HasSuffix(version, ".*"):
+		spec.wildcard = true
+		spec.prefix = appendVersionSplit(nil, strings.TrimSuffix(version, ".*"))
+	default:
+		spec.parsed = MustParse(version)
+		if operator == OpCompatible {
+			spec.prefix = compatiblePrefix(version)
+		}
+	}
+
+	// Like packaging, only the inclusive operators allow pre-releases when the spec version is a pre-release
+	// https://github.com/pypa/packaging/blob/a6407e3a7e19bd979e93f58cfc7f6641a7378c46/packaging/specifiers.py#L271-L291
+	switch spec.op {
+	case OpEqual, OpGreaterThanEqual, OpLessThanEqual, OpCompatible, OpArbitrary:
+		if v, err := Parse(strings.TrimSuffix(version, ".*")); err == nil {
+			spec.preRelease = v.IsPreRelease()
+		}
+	}
+
+	return spec, nil
 }
 
-func validate(operator, version string) error {
+// normalizeOperator maps the equality operators not defined in PEP 440 to OpEqual.
+func normalizeOperator(op Operator) Operator {
+	if op == "" || op == "=" {
+		return OpEqual
+	}
+	return op
+}
+
+// compatiblePrefix returns the split prefix of the == specifier equivalent to ~=version.
+func compatiblePrefix(version string) []string {
+	var prefix []string
+	for _, s := range appendVersionSplit(nil, version) {
+		if strings.HasPrefix(s, "post") || strings.HasPrefix(s, "dev") {
+			break
+		}
+		prefix = append(prefix, s)
+	}
+
+	// We want everything but the last item in the version, but we want to ignore post and dev releases and
+	// we want to treat the pre-release as it's own separate segment.
+	return prefix[:len(prefix)-1]
+}
+
+// suggestInput returns the input with the comma-separated clause around offset corrected by suggestSpecifier.
+// The clause is searched for within input[start:end].
+func suggestInput(input string, start, end, offset int) string {
+	if i := strings.LastIndex(input[start:offset], ","); i >= 0 {
+		start += i + 1
+	}
+	if i := strings.Index(input[offset:end], ","); i >= 0 {
+		end = offset + i
+	}
+
+	clause := strings.TrimSpace(input[start:end])
+	start += strings.Index(input[start:end], clause)
+
+	suggestion := suggestSpecifier(clause)
+	if suggestion == "" {
+		return ""
+	}
+	return input[:start] + suggestion + input[start+len(clause):]
+}
+
+// suggestSpecifier returns a corrected specifier if the given malformed specifier
+// has a typo in the operator (e.g. =>1.0) or a repairable version (e.g. >=1.0.0.RELEASE).
+func suggestSpecifier(s string) string {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !strings.ContainsRune("=<>!~^", r)
+	})
+	if i <= 0 {
+		return ""
+	}
+
+	operator, version := s[:i], s[i:]
+	if o, ok := operatorTypos[operator]; ok {
+		operator = o
+	}
+	if _, err := Parse(version); err != nil {
+		version = repairVersion(version)
+	}
+
+	suggestion := operator + version
+	if suggestion == s || !validConstraintRegexp().MatchString(suggestion) {
+		return ""
+	}
+	if _, err := newSpecifier(suggestion); err != nil {
+		return ""
+	}
+	return suggestion
+}
+
+func validate(operator Operator, version string) error {
 	hasWildcard := false
 	if strings.HasSuffix(version, ".*") {
 		hasWildcard = true
@@ -132,28 +409,29 @@ func validate(operator, version string) error {
 	}
 	v, err := Parse(version)
 	if err != nil {
-		return xerrors.Errorf("version parse error (%s): %w", v, err)
+		return xerrors.Errorf("version parse error: %w", err)
 	}
 
 	switch operator {
-	case "", "=", "==", "!=":
+	case "", "=", OpEqual, OpNotEqual:
 		if hasWildcard && (!v.dev.isNull() || v.local != "") {
-			return xerrors.New("the (non)equality operators don't allow to use a wild card and a dev" +
-				" or local version together")
+			return xerrors.Errorf("the (non)equality operators don't allow to use a wild card and a dev"+
+				" or local version together: %w", ErrWildcardNotAllowed)
 		}
-	case "~=":
+	case OpCompatible:
 		if hasWildcard {
-			return xerrors.New("a wild card is not allowed")
+			return ErrWildcardNotAllowed
 		} else if len(v.release) < 2 {
-			return xerrors.New("the compatible operator requires at least two digits in the release segment")
+			return xerrors.Errorf("the compatible operator requires at least two digits in the release segment: %w",
+				ErrInvalidSpecifier)
 		} else if v.local != "" {
-			return xerrors.New("local versions cannot be specified")
+			return ErrLocalNotAllowed
 		}
 	default:
 		if hasWildcard {
-			return xerrors.New("a wild card is not allowed")
+			return ErrWildcardNotAllowed
 		} else if v.local != "" {
-			return xerrors.New("local versions cannot be specified")
+			return ErrLocalNotAllowed
 		}
 	}
 	return nil
@@ -161,12 +439,18 @@ func validate(operator, version string) error {
 
 // Check tests if a version satisfies all the specifiers.
 func (ss Specifiers) Check(v Version) bool {
-	if ss.conf.includePreRelease {
+	return ss.check(v, nil)
+}
+
+// check is Check testing the clauses with spans set in wildcards against the spans instead, which CheckAll
+// precomputes to compare wildcards numerically rather than by formatting every version.
+func (ss Specifiers) check(v Version, wildcards [][]cutSpans) bool {
+	if ss.conf.preRelease == PreReleaseAllow {
 		v.preReleaseIncluded = true
 	}
 
-	for _, s := range ss.specifiers {
-		if andCheck(v, s) {
+	for i, and := range ss.specifiers {
+		if ss.conf.admits(v, and) && andCheckSpans(v, and, wildcards, i) {
 			return true
 		}
 	}
@@ -174,8 +458,345 @@ func (ss Specifiers) Check(v Version) bool {
 	return false
 }
 
+// andCheckSpans is andCheck testing the clauses of the i-th group with spans in wildcards against the spans.
+func andCheckSpans(v Version, and []specifier, wildcards [][]cutSpans, i int) bool {
+	if wildcards == nil || wildcards[i] == nil {
+		return andCheck(v, and)
+	}
+	for j, s := range and {
+		if spans := wildcards[i][j]; spans != nil {
+			if v.infinity != 0 || !spans.contains(v) {
+				return false
+			}
+		} else if !s.check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ClauseResult describes how a clause of Specifiers judged a version.
+type ClauseResult struct {
+	// Group is the index of the OR group of the clause, as returned by Groups.
+	Group int
+	Specifier
+	// Satisfied reports whether the version satisfies the clause.
+	Satisfied bool
+	// Reason explains why the clause rejected the version, e.g. "pre-release excluded". It is empty if Satisfied.
+	Reason string
+}
+
+// CheckDetail is like Check, but also reports the result of every clause of every OR group,
+// so that callers can explain why a version was rejected.
+func (ss Specifiers) CheckDetail(v Version) (bool, []ClauseResult) {
+	if ss.conf.preRelease == PreReleaseAllow {
+		v.preReleaseIncluded = true
+	}
+
+	var ok bool
+	var results []ClauseResult
+	for i, and := range ss.specifiers {
+		admitted := ss.conf.admits(v, and)
+		satisfied := true
+		for _, s := range and {
+			r := ClauseResult{Group: i, Specifier: Specifier{Operator: s.op, Version: s.version}, Satisfied: s.check(v)}
+			switch {
+			case !r.Satisfied:
+				r.Reason = s.explain(v)
+			case !admitted:
+				r.Satisfied = false
+				r.Reason = "pre-release excluded"
+			}
+			if !r.Satisfied {
+				satisfied = false
+			}
+			results = append(results, r)
+		}
+		ok = ok || satisfied
+	}
+	return ok, results
+}
+
+// VersionResult is the result of checking a version in a Report.
+type VersionResult struct {
+	Version Version
+	// Matched reports whether the version satisfies the specifiers.
+	Matched bool
+	// ExcludedBy are the clauses rejecting the version with their reasons as returned by CheckDetail,
+	// at least one of every OR group. It is empty if Matched.
+	ExcludedBy []ClauseResult
+}
+
+// Report is the result of checking candidates against Specifiers.
+type Report struct {
+	// Results are the results of the candidates in their order.
+	Results []VersionResult
+	// Matched and Rejected are the numbers of candidates satisfying and not satisfying the specifiers.
+	Matched, Rejected int
+	// Excluded is the number of rejected candidates per clause rejecting them, e.g. 3 for "<2.0".
+	Excluded map[Specifier]int
+}
+
+// Report checks every candidate like CheckDetail and returns the results with the clauses rejecting each one,
+// so that the specifiers can be audited against a release history.
+func (ss Specifiers) Report(candidates []Version) Report {
+	r := Report{
+		Results:  make([]VersionResult, len(candidates)),
+		Excluded: make(map[Specifier]int),
+	}
+	for i, v := range candidates {
+		ok, results := ss.CheckDetail(v)
+		r.Results[i] = VersionResult{Version: v, Matched: ok}
+		if ok {
+			r.Matched++
+			continue
+		}
+
+		r.Rejected++
+		var counted []Specifier
+		for _, c := range results {
+			if c.Satisfied {
+				continue
+			}
+			r.Results[i].ExcludedBy = append(r.Results[i].ExcludedBy, c)
+			// A clause repeated in several OR groups rejects the version once
+			if !slices.Contains(counted, c.Specifier) {
+				counted = append(counted, c.Specifier)
+				r.Excluded[c.Specifier]++
+			}
+		}
+	}
+	return r
+}
+
+// Group is one of the OR groups of Specifiers. It is satisfied by a version satisfying all of its clauses.
+type Group struct {
+	Clauses []Specifier
+}
+
+// String returns the clauses joined by commas, e.g. ">=1.0,<2.0".
+func (g Group) String() string {
+	clauses := make([]string, len(g.Clauses))
+	for i, c := range g.Clauses {
+		clauses[i] = c.String()
+	}
+	return strings.Join(clauses, ",")
+}
+
+// Groups returns the OR groups of the specifiers, e.g. [>=1.0,<2.0 ==3.*] for ">=1.0, <2.0 || ==3.*".
+func (ss Specifiers) Groups() []Group {
+	groups := make([]Group, len(ss.specifiers))
+	for i, and := range ss.specifiers {
+		groups[i].Clauses = make([]Specifier, len(and))
+		for j, s := range and {
+			groups[i].Clauses[j] = Specifier{Operator: s.op, Version: s.version}
+		}
+	}
+	return groups
+}
+
+// Clauses returns the clauses of the specifiers as OR groups of AND clauses,
+// e.g. [[>=1.0 <2.0] [==3.*]] for ">=1.0, <2.0 || ==3.*".
+func (ss Specifiers) Clauses() [][]Specifier {
+	groups := ss.Groups()
+	clauses := make([][]Specifier, len(groups))
+	for i, g := range groups {
+		clauses[i] = g.Clauses
+	}
+	return clauses
+}
+
+// And returns specifiers satisfied by the versions satisfying both ss and other. The OR groups are
+// distributed so that the result keeps the OR-of-ANDs structure, e.g. (a || b) and c is a,c || b,c.
+// The options of ss are kept.
+func (ss Specifiers) And(other Specifiers) Specifiers {
+	var groups [][]specifier
+	for _, and1 := range ss.specifiers {
+		for _, and2 := range other.specifiers {
+			group := make([]specifier, 0, len(and1)+len(and2))
+			group = append(append(group, and1...), and2...)
+			groups = append(groups, group)
+		}
+	}
+	return Specifiers{
+		specifiers: groups,
+		conf:       ss.conf,
+	}
+}
+
+// Or returns specifiers satisfied by the versions satisfying either ss or other.
+// The OR groups of other are appended to those of ss. The options of ss are kept.
+func (ss Specifiers) Or(other Specifiers) Specifiers {
+	groups := make([][]specifier, 0, len(ss.specifiers)+len(other.specifiers))
+	groups = append(append(groups, ss.specifiers...), other.specifiers...)
+	return Specifiers{
+		specifiers: groups,
+		conf:       ss.conf,
+	}
+}
+
+// CheckAll tests each of the given versions like Check. The result at index i is for versions[i].
+// It is meant for checking large numbers of candidates against the same specifiers: the wildcard clauses are
+// turned into ranges of versions once, so that each version is compared by its numeric segments instead of
+// being formatted and split for prefix matching.
+func (ss Specifiers) CheckAll(versions []Version) []bool {
+	wildcards := ss.wildcardSpans()
+	results := make([]bool, len(versions))
+	for i, v := range versions {
+		results[i] = ss.check(v, wildcards)
+	}
+	return results
+}
+
+// wildcardSpans returns the spans of the wildcard clauses of each group which match exactly the versions in them,
+// and nil for the other clauses. It returns nil if there are no such clauses.
+func (ss Specifiers) wildcardSpans() [][]cutSpans {
+	var wildcards [][]cutSpans
+	for i, and := range ss.specifiers {
+		for j, s := range and {
+			if !s.wildcard || !ss.conf.exact(s) {
+				continue
+			}
+			if wildcards == nil {
+				wildcards = make([][]cutSpans, len(ss.specifiers))
+			}
+			if wildcards[i] == nil {
+				wildcards[i] = make([]cutSpans, len(and))
+			}
+			wildcards[i][j] = s.spans(false)
+		}
+	}
+	return wildcards
+}
+
+// CheckAllStrings parses and tests each of the given versions like CheckAll. Malformed versions don't satisfy
+// the specifiers, and the returned error joins the parse failures, each annotated with its index in versions.
+func (ss Specifiers) CheckAllStrings(versions []string) ([]bool, error) {
+	wildcards := ss.wildcardSpans()
+	results := make([]bool, len(versions))
+	var errs []error
+	for i, v := range versions {
+		ver, err := Parse(v)
+		if err != nil {
+			errs = append(errs, xerrors.Errorf("index %d: %w", i, err))
+			continue
+		}
+		results[i] = ss.check(ver, wildcards)
+	}
+	return results, errors.Join(errs...)
+}
+
+// Filter returns the versions that satisfy the specifiers, in the order they appear in versions,
+// like SpecifierSet.filter in Python's packaging. Under PreReleaseAuto, the pre-releases satisfying the specifiers
+// are returned if no other version does, e.g. ">=1.0" filters [0.9, 2.0a1] to [2.0a1].
+func (ss Specifiers) Filter(versions []Version) []Version {
+	var filtered []Version
+	for i, ok := range ss.CheckAll(versions) {
+		if ok {
+			filtered = append(filtered, versions[i])
+		}
+	}
+	if filtered == nil && ss.conf.preRelease == PreReleaseAuto {
+		return ss.preReleaseFallback().Filter(versions)
+	}
+	return filtered
+}
+
+// Latest returns the highest of the candidates satisfying the specifiers, honoring the pre-release policy like
+// Filter. If several candidates are equal (e.g. 1.0 and 1.0.0), the first one is returned.
+// It returns false if no candidate satisfies the specifiers.
+func (ss Specifiers) Latest(candidates []Version) (Version, bool) {
+	return pick(ss.Filter(candidates), 1)
+}
+
+// Earliest returns the lowest of the candidates satisfying the specifiers, like Latest.
+// It is useful for checking the minimum supported versions, e.g. like pip install --resolution=lowest.
+func (ss Specifiers) Earliest(candidates []Version) (Version, bool) {
+	return pick(ss.Filter(candidates), -1)
+}
+
+// AllowsUpgrade reports whether the candidate is an upgrade from the current version which still satisfies
+// the specifiers, i.e. it is greater than the current version and satisfies them. The current version needn't
+// satisfy the specifiers itself.
+func (ss Specifiers) AllowsUpgrade(current, candidate Version) bool {
+	return candidate.GreaterThan(current) && ss.Check(candidate)
+}
+
+// pick returns the first of the versions comparing as sign (1 or -1) to all the others.
+func pick(versions []Version, sign int) (Version, bool) {
+	if len(versions) == 0 {
+		return Version{}, false
+	}
+	best := versions[0]
+	for _, v := range versions[1:] {
+		if v.Compare(best) == sign {
+			best = v
+		}
+	}
+	return best, true
+}
+
+// preReleaseFallback returns the specifiers matching the pre-releases held back by PreReleaseAuto.
+func (ss Specifiers) preReleaseFallback() Specifiers {
+	ss.conf.preRelease = PreReleaseDefault
+	return ss
+}
+
+// Satisfies parses the given specifiers and tests if the version satisfies them.
+func (v Version) Satisfies(specifiers string, opts ...SpecifierOption) (bool, error) {
+	ss, err := NewSpecifiers(specifiers, opts...)
+	if err != nil {
+		return false, err
+	}
+	return ss.Check(v), nil
+}
+
 func (s specifier) check(v Version) bool {
-	return s.operator(v, s.version)
+	return v.infinity == 0 && s.operator(v, s)
+}
+
+// explain returns why the clause rejects the given version.
+func (s specifier) explain(v Version) string {
+	if v.infinity != 0 {
+		return v.String() + " is not a version"
+	}
+	switch s.op {
+	case OpCompatible:
+		if !specifierGreaterThanEqual(v, s) {
+			return "lower than " + s.version
+		}
+		return "not a " + strings.Join(s.prefix, ".") + ".* release"
+	case OpEqual:
+		if s.wildcard {
+			return "does not match " + s.version
+		}
+		return "not equal to " + s.version
+	case OpNotEqual:
+		if s.wildcard {
+			return "matches " + s.version
+		}
+		return "equal to " + s.version
+	case OpLessThan:
+		if !v.LessThan(s.parsed) {
+			return "not lower than " + s.version
+		}
+		return "pre-release excluded"
+	case OpGreaterThan:
+		switch {
+		case !v.GreaterThan(s.parsed):
+			return "not greater than " + s.version
+		case v.IsPostRelease():
+			return "post-release excluded"
+		}
+		return "local version excluded"
+	case OpLessThanEqual:
+		return "greater than " + s.version
+	case OpGreaterThanEqual:
+		return "lower than " + s.version
+	case OpArbitrary:
+		return "not identical to " + s.version
+	}
+	return ""
 }
 
 func (s specifier) String() string {
@@ -196,6 +817,68 @@ func (ss Specifiers) String() string {
 	return strings.Join(ssStr, "||")
 }
 
+// Canonical returns a normalized form of the specifiers which is identical for specifiers that differ only
+// in spacing, order or spelling, e.g. "<2, >= 1.0.0-rc1 || 3" and "==3||>=1.0.0rc1,<2" have the same form.
+// The operators and versions are normalized, and the clauses and the OR groups are sorted and deduplicated.
+func (ss Specifiers) Canonical() string {
+	groups := make([]string, 0, len(ss.specifiers))
+	for _, and := range ss.specifiers {
+		clauses := make([]string, 0, len(and))
+		for _, s := range and {
+			clauses = append(clauses, s.canonical())
+		}
+		slices.Sort(clauses)
+		groups = append(groups, strings.Join(slices.Compact(clauses), ","))
+	}
+	slices.Sort(groups)
+	return strings.Join(slices.Compact(groups), "||")
+}
+
+// canonical returns the clause with the operator and the version normalized.
+func (s specifier) canonical() string {
+	switch {
+	case s.op == OpArbitrary:
+		return string(s.op) + strings.ToLower(s.version)
+	case s.wildcard:
+		return string(s.op) + MustParse(strings.TrimSuffix(s.version, ".*")).String() + ".*"
+	}
+	return string(s.op) + s.parsed.String()
+}
+
+// Equal reports whether the specifiers have the same canonical form and pre-release policy.
+func (ss Specifiers) Equal(other Specifiers) bool {
+	return ss.conf.preRelease == other.conf.preRelease && ss.Canonical() == other.Canonical()
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (ss Specifiers) MarshalText() ([]byte, error) {
+	return []byte(ss.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (ss *Specifiers) UnmarshalText(data []byte) error {
+	var err error
+	*ss, err = NewSpecifiers(string(data))
+	return err
+}
+
+// admits reports whether the pre-release policy lets the given OR group match the version.
+func (c conf) admits(v Version, specifiers []specifier) bool {
+	switch {
+	case c.preRelease != PreReleaseDeny && c.preRelease != PreReleaseAuto:
+		return true
+	case !v.IsPreRelease():
+		return true
+	case c.preRelease == PreReleaseAuto:
+		for _, s := range specifiers {
+			if s.preRelease {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func andCheck(v Version, specifiers []specifier) bool {
 	for _, c := range specifiers {
 		if !c.check(v) {
@@ -205,155 +888,177 @@ func andCheck(v Version, specifiers []specifier) bool {
 	return true
 }
 
-func versionSplit(version string) []string {
-	var result []string
-	for _, v := range strings.Split(version, ".") {
-		m := prefixRegexp.FindStringSubmatch(v)
-		if m != nil {
-			result = append(result, m[1:]...)
+// appendVersionSplit appends the segments of the given version split by dots to dst,
+// pretending that there is an implicit dot in between a release segment and a pre-release segment.
+func appendVersionSplit(dst []string, version string) []string {
+	for {
+		segment, rest, found := strings.Cut(version, ".")
+		if release, pre, ok := cutPreRelease(segment); ok {
+			dst = append(dst, release, pre)
 		} else {
-			result = append(result, v)
+			dst = append(dst, segment)
+		}
+		if !found {
+			return dst
 		}
+		version = rest
 	}
-	return result
 }
 
-func isDigist(s string) bool {
-	if _, err := strconv.Atoi(s); err == nil {
-		return true
+// cutPreRelease splits a segment such as "1rc2" into its release and pre-release parts.
+func cutPreRelease(segment string) (release, pre string, ok bool) {
+	i := 0
+	for i < len(segment) && isDigit(segment[i]) {
+		i++
 	}
-	return false
+	if i == 0 {
+		return "", "", false
+	}
+
+	j := i
+	if strings.HasPrefix(segment[j:], "rc") {
+		j += 2
+	} else if j < len(segment) && (segment[j] == 'a' || segment[j] == 'b' || segment[j] == 'c') {
+		j++
+	} else {
+		return "", "", false
+	}
+
+	if j == len(segment) || !isDigits(segment[j:]) {
+		return "", "", false
+	}
+	return segment[:i], segment[i:], true
 }
 
-func padVersion(left, right []string) ([]string, []string) {
-	var leftRelease, rightRelease []string
-	for _, l := range left {
-		if isDigist(l) {
-			leftRelease = append(leftRelease, l)
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
 		}
 	}
+	return s != ""
+}
 
-	for _, r := range right {
-		if isDigist(r) {
-			rightRelease = append(rightRelease, r)
+// releaseLen returns the number of leading numeric segments.
+func releaseLen(segments []string) int {
+	for i, s := range segments {
+		if !isDigits(s) {
+			return i
 		}
 	}
+	return len(segments)
+}
 
-	// Get the rest of our versions
-	leftRest := left[len(leftRelease):]
-	rightRest := left[len(rightRelease):]
+// prefixMatch reports whether the split spec is a prefix of the split prospective version.
+// The release segments are compared as if the shorter one were padded with zeros.
+// https://github.com/pypa/packaging/blob/a6407e3a7e19bd979e93f58cfc7f6641a7378c46/packaging/specifiers.py#L779-L793
+func prefixMatch(spec, prospective []string) bool {
+	// Shorten the prospective version to be the same length as the spec
+	// so that we can determine if the specifier is a prefix of the
+	// prospective version or not.
+	if len(prospective) > len(spec) {
+		prospective = prospective[:len(spec)]
+	}
 
-	for i := 0; i < len(leftRelease)-len(rightRelease); i++ {
-		rightRelease = append(rightRelease, "0")
+	specRelease, prospectiveRelease := releaseLen(spec), releaseLen(prospective)
+	for i := 0; i < max(specRelease, prospectiveRelease); i++ {
+		if paddedSegment(spec, specRelease, i) != paddedSegment(prospective, prospectiveRelease, i) {
+			return false
+		}
 	}
-	for i := 0; i < len(rightRelease)-len(leftRelease); i++ {
-		leftRelease = append(leftRelease, "0")
+
+	// Compare the rest of our versions
+	spec, prospective = spec[specRelease:], prospective[prospectiveRelease:]
+	if len(spec) != len(prospective) {
+		return false
 	}
+	for i := range spec {
+		if spec[i] != prospective[i] {
+			return false
+		}
+	}
+	return true
+}
 
-	return append(leftRelease, leftRest...), append(rightRelease, rightRest...)
+// paddedSegment returns the i-th release segment, or "0" if the release has fewer segments.
+func paddedSegment(segments []string, releaseLen, i int) string {
+	if i < releaseLen {
+		return segments[i]
+	}
+	return "0"
 }
 
 //-------------------------------------------------------------------
 // Specifier functions
 //-------------------------------------------------------------------
 
-func specifierCompatible(prospective Version, spec string) bool {
+func specifierCompatible(prospective Version, s specifier) bool {
 	// Compatible releases have an equivalent combination of >= and ==. That is that ~=2.2 is equivalent to >=2.2,==2.*.
 	// This allows us to implement this in terms of the other specifiers instead of implementing it ourselves.
-	// The only thing we need to do is construct the other specifiers.
-
-	var prefixElements []string
-	for _, s := range versionSplit(spec) {
-		if strings.HasPrefix(s, "post") || strings.HasPrefix(s, "dev") {
-			break
-		}
-		prefixElements = append(prefixElements, s)
-	}
-
-	// We want everything but the last item in the version, but we want to ignore post and dev releases and
-	// we want to treat the pre-release as it's own separate segment.
-	prefix := strings.Join(prefixElements[:len(prefixElements)-1], ".")
-
-	// Add the prefix notation to the end of our string
-	prefix += ".*"
-
-	return specifierGreaterThanEqual(prospective, spec) && specifierEqual(prospective, prefix)
+	// The prefix of the == specifier is computed by newSpecifier.
+	return specifierGreaterThanEqual(prospective, s) && matchPrefix(s.prefix, prospective)
 }
 
-func specifierEqual(prospective Version, spec string) bool {
+func specifierEqual(prospective Version, s specifier) bool {
 	// https://github.com/pypa/packaging/blob/a6407e3a7e19bd979e93f58cfc7f6641a7378c46/packaging/specifiers.py#L476
 	// We need special logic to handle prefix matching
-	if strings.HasSuffix(spec, ".*") {
-		// In the case of prefix matching we want to ignore local segment.
-		prospective = MustParse(prospective.Public())
-
-		// Split the spec out by dots, and pretend that there is an implicit
-		// dot in between a release segment and a pre-release segment.
-		splitSpec := versionSplit(strings.TrimSuffix(spec, ".*"))
-
-		// Split the prospective version out by dots, and pretend that there is an implicit dot
-		//  in between a release segment and a pre-release segment.
-		splitProspective := versionSplit(prospective.String())
-
-		// Shorten the prospective version to be the same length as the spec
-		// so that we can determine if the specifier is a prefix of the
-		// prospective version or not.
-		if len(splitProspective) > len(splitSpec) {
-			splitProspective = splitProspective[:len(splitSpec)]
-		}
-
-		paddedSpec, paddedProspective := padVersion(splitSpec, splitProspective)
-		return reflect.DeepEqual(paddedSpec, paddedProspective)
+	if s.wildcard {
+		return matchPrefix(s.prefix, prospective)
 	}
 
-	specVersion := MustParse(spec)
-	if specVersion.local == "" {
-		prospective = MustParse(prospective.Public())
+	if s.parsed.local == "" {
+		prospective = prospective.withoutLocal()
 	}
 
-	return specVersion.Equal(prospective)
+	return s.parsed.Equal(prospective)
 }
 
-func specifierNotEqual(prospective Version, spec string) bool {
-	return !specifierEqual(prospective, spec)
+// matchPrefix reports whether the split prefix matches the prospective version.
+func matchPrefix(prefix []string, prospective Version) bool {
+	// Split the prospective version out by dots, and pretend that there is an implicit dot
+	// in between a release segment and a pre-release segment.
+	// In the case of prefix matching we want to ignore local segment.
+	var buf [8]string
+	var public [64]byte
+	splitProspective := appendVersionSplit(buf[:0], string(prospective.appendPublic(public[:0])))
+
+	return prefixMatch(prefix, splitProspective)
 }
 
-func specifierLessThan(prospective Version, spec string) bool {
-	// Convert our spec to a Version instance, since we'll want to work with it as a version.
-	s := MustParse(spec)
+func specifierNotEqual(prospective Version, s specifier) bool {
+	return !specifierEqual(prospective, s)
+}
 
+func specifierLessThan(prospective Version, s specifier) bool {
 	// Check to see if the prospective version is less than the spec version.
 	// If it's not we can short circuit and just return False now instead of doing extra unneeded work.
-	if !prospective.LessThan(s) {
+	if !prospective.LessThan(s.parsed) {
 		return false
 	}
 
 	// This special case is here so that, unless the specifier itself includes is a pre-release version,
 	// that we do not accept pre-release versions for the version mentioned in the specifier
 	// (e.g. <3.1 should not match 3.1.dev0, but should match 3.0.dev0).
-	if !s.IsPreRelease() && prospective.IsPreRelease() {
-		if MustParse(prospective.BaseVersion()).Equal(MustParse(s.BaseVersion())) {
+	if !s.parsed.IsPreRelease() && prospective.IsPreRelease() {
+		if CompareRelease(prospective, s.parsed) == 0 {
 			return false
 		}
 	}
 	return true
 }
 
-func specifierGreaterThan(prospective Version, spec string) bool {
-	// Convert our spec to a Version instance, since we'll want to work with it as a version.
-	s := MustParse(spec)
-
+func specifierGreaterThan(prospective Version, s specifier) bool {
 	// Check to see if the prospective version is greater than the spec version.
 	// If it's not we can short circuit and just return False now instead of doing extra unneeded work.
-	if !prospective.GreaterThan(s) {
+	if !prospective.GreaterThan(s.parsed) {
 		return false
 	}
 
 	// This special case is here so that, unless the specifier itself includes is a post-release version,
 	// that we do not accept post-release versions for the version mentioned in the specifier
 	// (e.g. >3.1 should not match 3.0.post0, but should match 3.2.post0).
-	if !s.IsPostRelease() && prospective.IsPostRelease() {
-		if MustParse(prospective.BaseVersion()).Equal(MustParse(s.BaseVersion())) {
+	if !s.parsed.IsPostRelease() && prospective.IsPostRelease() {
+		if CompareRelease(prospective, s.parsed) == 0 {
 			return false
 		}
 	}
@@ -361,25 +1066,21 @@ func specifierGreaterThan(prospective Version, spec string) bool {
 	// Ensure that we do not allow a local version of the version mentioned
 	//  in the specifier, which is technically greater than, to match.
 	if prospective.local != "" {
-		if MustParse(prospective.BaseVersion()).Equal(MustParse(s.BaseVersion())) {
+		if CompareRelease(prospective, s.parsed) == 0 {
 			return false
 		}
 	}
 	return true
 }
 
-func specifierArbitrary(prospective Version, spec string) bool {
-	return strings.EqualFold(prospective.String(), spec)
+func specifierArbitrary(prospective Version, s specifier) bool {
+	return strings.EqualFold(prospective.String(), s.version)
 }
 
-func specifierLessThanEqual(prospective Version, spec string) bool {
-	p := MustParse(prospective.Public())
-	s := MustParse(spec)
-	return p.LessThanOrEqual(s)
+func specifierLessThanEqual(prospective Version, s specifier) bool {
+	return prospective.withoutLocal().LessThanOrEqual(s.parsed)
 }
 
-func specifierGreaterThanEqual(prospective Version, spec string) bool {
-	p := MustParse(prospective.Public())
-	s := MustParse(spec)
-	return p.GreaterThanOrEqual(s)
+func specifierGreaterThanEqual(prospective Version, s specifier) bool {
+	return prospective.withoutLocal().GreaterThanOrEqual(s.parsed)
 }
@@ -1,15 +1,75 @@
 package version
 
 type conf struct {
-	includePreRelease bool
+	preRelease      PreReleasePolicy
+	maxLength       int
+	maxClauses      int
+	requireOperator bool
 }
 
 type SpecifierOption interface {
 	apply(*conf)
 }
 
+// WithPreRelease(true) is the same as WithPreReleasePolicy(PreReleaseAllow), and WithPreRelease(false)
+// is the same as WithPreReleasePolicy(PreReleaseDefault).
+//
+// Deprecated: Use WithPreReleasePolicy.
 type WithPreRelease bool
 
 func (o WithPreRelease) apply(c *conf) {
-	c.includePreRelease = bool(o)
+	if o {
+		c.preRelease = PreReleaseAllow
+	} else {
+		c.preRelease = PreReleaseDefault
+	}
+}
+
+// PreReleasePolicy controls whether Specifiers match pre-release versions.
+type PreReleasePolicy int
+
+const (
+	// PreReleaseDefault matches pre-releases like other versions, except that < doesn't match
+	// the pre-releases of the spec version, e.g. "<2.0" doesn't match 2.0a1.
+	PreReleaseDefault PreReleasePolicy = iota
+	// PreReleaseAllow matches pre-releases like other versions, e.g. "<2.0" matches 2.0a1.
+	PreReleaseAllow
+	// PreReleaseDeny never matches pre-releases.
+	PreReleaseDeny
+	// PreReleaseAuto matches pre-releases only in OR groups with a clause naming a pre-release (e.g. ">=2.0b1"),
+	// like SpecifierSet with prereleases=None in Python's packaging.
+	// Otherwise, it behaves like PreReleaseDefault.
+	PreReleaseAuto
+)
+
+// WithPreReleasePolicy sets how pre-release versions are matched. The default is PreReleaseDefault.
+type WithPreReleasePolicy PreReleasePolicy
+
+func (o WithPreReleasePolicy) apply(c *conf) {
+	c.preRelease = PreReleasePolicy(o)
+}
+
+// WithRequireOperator rejects clauses without an operator (e.g. "2.0"), which are otherwise treated as ==,
+// with ErrMissingOperator. It catches typos silently turning into equality constraints.
+type WithRequireOperator bool
+
+func (o WithRequireOperator) apply(c *conf) {
+	c.requireOperator = bool(o)
+}
+
+// WithMaxSpecifierLength rejects specifiers longer than the given number of bytes with ErrInputTooLong,
+// before any parsing is done. It is meant for parsing untrusted input such as requirement files.
+// Zero or a negative number means no limit.
+type WithMaxSpecifierLength int
+
+func (o WithMaxSpecifierLength) apply(c *conf) {
+	c.maxLength = int(o)
+}
+
+// WithMaxClauses rejects specifiers with more than the given number of clauses (e.g. ">=1.0, <2.0 || ==3.*"
+// has three) with ErrTooManyClauses. Zero or a negative number means no limit.
+type WithMaxClauses int
+
+func (o WithMaxClauses) apply(c *conf) {
+	c.maxClauses = int(o)
 }
@@ -115,6 +115,8 @@ func TestVersion_Check(t *testing.T) {
 		{"2.0.post1", "==2.0.post1.*", true},
 		{"2.0.post1.dev1", "==2.0.post1.*", true},
 		{"2.1+local.version", "==2.1.*", true},
+		{"1.0rc1.post1", "==1.0rc1.*", true},
+		{"1.0rc1", "==1.0.0rc1.*", true},
 
 		// Test the in-equality operation
 		{"2.1", "!=2", true},
@@ -191,6 +193,8 @@ func TestVersion_Check(t *testing.T) {
 		//Test the equality operation with a prefix
 		{"2.0", "==3.*", false},
 		{"2.1", "==2.0.*", false},
+		{"1.0rc2", "==1.0rc1.*", false},
+		{"1.0rc1.post1", "==1.0.0rc2.*", false},
 
 		// Test the in-equality operation
 		{"2.0", "!=2", false},
@@ -356,3 +360,674 @@ func TestVersion_CheckWithPreRelease(t *testing.T) {
 		})
 	}
 }
+
+func TestVersion_Satisfies(t *testing.T) {
+	tests := []struct {
+		version string
+		spec    string
+		opts    []SpecifierOption
+		want    bool
+		wantErr bool
+	}{
+		{version: "1.5", spec: ">=1.0,<2.0", want: true},
+		{version: "2.0", spec: ">=1.0,<2.0", want: false},
+		{version: "2.0a1", spec: "<2", want: false},
+		{version: "2.0a1", spec: "<2", opts: []SpecifierOption{WithPreRelease(true)}, want: true},
+		{version: "1.0", spec: "=>1.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.version, tt.spec), func(t *testing.T) {
+			got, err := MustParse(tt.version).Satisfies(tt.spec, tt.opts...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func BenchmarkSpecifierPrefixMatch(b *testing.B) {
+	ss, err := NewSpecifiers("==1.2.*")
+	require.NoError(b, err)
+	v := MustParse("1.2.3rc1")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ss.Check(v)
+	}
+}
+
+func BenchmarkSpecifierEqual(b *testing.B) {
+	ss, err := NewSpecifiers("==1.2.3")
+	require.NoError(b, err)
+	v := MustParse("1.2.3+local")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ss.Check(v)
+	}
+}
+
+func TestSpecifiers_CheckAll(t *testing.T) {
+	ss, err := NewSpecifiers(">=1.0, <2.0 || ==3.*")
+	require.NoError(t, err)
+
+	vs, err := ParseAll([]string{"0.9", "1.0", "1.5.post1", "2.0", "3.1", "2.0a1"})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, true, true, false, true, false}, ss.CheckAll(vs))
+
+	ss, err = NewSpecifiers("<2.0", WithPreRelease(true))
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true, true, false, false, true}, ss.CheckAll(vs))
+	assert.Empty(t, ss.CheckAll(nil))
+}
+
+func TestSpecifiers_CheckAllStrings(t *testing.T) {
+	ss, err := NewSpecifiers(">=1.0, <2.0")
+	require.NoError(t, err)
+
+	got, err := ss.CheckAllStrings([]string{"1.0", "french toast", "2.0", "1.1"})
+	assert.Equal(t, []bool{true, false, false, true}, got)
+	assert.ErrorIs(t, err, ErrInvalidVersion)
+	assert.ErrorContains(t, err, "index 1: ")
+
+	got, err = ss.CheckAllStrings([]string{"1.0", "2.0"})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, got)
+}
+
+func TestSpecifiers_CheckAll_Check(t *testing.T) {
+	versions, err := ParseAll(append(rangeVersions, "1.0.0.0", "1.0+local.1", "2.0rc1.post1.dev1"))
+	require.NoError(t, err)
+	versions = append(versions, NegInf, Inf)
+
+	for _, s := range append(rangeSpecifiers, ">=1.0, !=1.0.*, <2.0 || ==2.*", "==1.*, !=1.0.1.*") {
+		for _, policy := range []PreReleasePolicy{PreReleaseDefault, PreReleaseAllow, PreReleaseDeny, PreReleaseAuto} {
+			ss, err := NewSpecifiers(s, WithPreReleasePolicy(policy))
+			require.NoError(t, err)
+			for i, got := range ss.CheckAll(versions) {
+				require.Equal(t, ss.Check(versions[i]), got, "%s (%d): %s", s, policy, versions[i])
+			}
+		}
+	}
+}
+
+func benchmarkCheckAll(b *testing.B, check func(Specifiers, []Version)) {
+	ss, err := NewSpecifiers(">=1.0, !=1.3.4.*, <2.0")
+	require.NoError(b, err)
+
+	vs := make([]Version, 1000)
+	for i := range vs {
+		vs[i] = MustParse(fmt.Sprintf("1.%d.%d", i/10, i%10))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		check(ss, vs)
+	}
+}
+
+func BenchmarkSpecifiers_CheckAll(b *testing.B) {
+	benchmarkCheckAll(b, func(ss Specifiers, vs []Version) {
+		ss.CheckAll(vs)
+	})
+}
+
+// BenchmarkSpecifiers_CheckAll_Loop is the baseline of BenchmarkSpecifiers_CheckAll calling Check for each version.
+func BenchmarkSpecifiers_CheckAll_Loop(b *testing.B) {
+	benchmarkCheckAll(b, func(ss Specifiers, vs []Version) {
+		results := make([]bool, len(vs))
+		for i, v := range vs {
+			results[i] = ss.Check(v)
+		}
+	})
+}
+
+func TestNewSpecifiers_Limits(t *testing.T) {
+	_, err := NewSpecifiers(">=1.0, <2.0", WithMaxSpecifierLength(11))
+	assert.NoError(t, err)
+
+	_, err = NewSpecifiers(">=1.0, <2.0", WithMaxSpecifierLength(10))
+	assert.ErrorIs(t, err, ErrInputTooLong)
+
+	_, err = NewSpecifiers(">=1.0, <2.0 || ==3.*", WithMaxClauses(3))
+	assert.NoError(t, err)
+
+	_, err = NewSpecifiers(">=1.0, <2.0 || ==3.*, !=3.1", WithMaxClauses(3))
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.ErrorIs(t, err, ErrTooManyClauses)
+	assert.ErrorIs(t, err, ErrInvalidSpecifier)
+	assert.Equal(t, 22, parseErr.Offset)
+	assert.Equal(t, "!=3.1", parseErr.Token)
+
+	_, err = NewSpecifiers(">=1.0 <2.0 !=1.5", WithMaxClauses(2))
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 11, parseErr.Offset)
+}
+
+func TestSpecifiers_Clauses(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		want       [][]Specifier
+	}{
+		{
+			specifiers: ">= 1.0, <2.0 || ==3.*",
+			want: [][]Specifier{
+				{{Operator: OpGreaterThanEqual, Version: "1.0"}, {Operator: OpLessThan, Version: "2.0"}},
+				{{Operator: OpEqual, Version: "3.*"}},
+			},
+		},
+		{
+			specifiers: "~=1.4.5 !=1.4.7",
+			want:       [][]Specifier{{{Operator: OpCompatible, Version: "1.4.5"}, {Operator: OpNotEqual, Version: "1.4.7"}}},
+		},
+		{
+			specifiers: "2.0",
+			want:       [][]Specifier{{{Operator: OpEqual, Version: "2.0"}}},
+		},
+		{
+			specifiers: "=1.0, !=1.1, <3, >0.1, <=2.5, ~=1.0",
+			want: [][]Specifier{{
+				{Operator: OpEqual, Version: "1.0"},
+				{Operator: OpNotEqual, Version: "1.1"},
+				{Operator: OpLessThan, Version: "3"},
+				{Operator: OpGreaterThan, Version: "0.1"},
+				{Operator: OpLessThanEqual, Version: "2.5"},
+				{Operator: OpCompatible, Version: "1.0"},
+			}},
+		},
+		{
+			specifiers: "===1.0",
+			want:       [][]Specifier{{{Operator: OpArbitrary, Version: "1.0"}}},
+		},
+		{
+			specifiers: "*",
+			want:       [][]Specifier{{{Operator: OpGreaterThanEqual, Version: "0.0.0"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ss.Clauses())
+		})
+	}
+
+	assert.Equal(t, ">=1.0", Specifier{Operator: OpGreaterThanEqual, Version: "1.0"}.String())
+	assert.Empty(t, Specifiers{}.Clauses())
+}
+
+func TestSpecifiers_AndOr(t *testing.T) {
+	a, err := NewSpecifiers(">=1.0 || ==0.5")
+	require.NoError(t, err)
+	b, err := NewSpecifiers("<2.0 || >=3.0, <4.0")
+	require.NoError(t, err)
+
+	and := a.And(b)
+	assert.Equal(t, ">=1.0,<2.0||>=1.0,>=3.0,<4.0||==0.5,<2.0||==0.5,>=3.0,<4.0", and.String())
+	or := a.Or(b)
+	assert.Equal(t, ">=1.0||==0.5||<2.0||>=3.0,<4.0", or.String())
+
+	for _, v := range []string{"0.4", "0.5", "1.0", "1.9", "2.0", "2.5", "3.0", "3.9", "4.0"} {
+		ver := MustParse(v)
+		assert.Equal(t, a.Check(ver) && b.Check(ver), and.Check(ver), "%s and", v)
+		assert.Equal(t, a.Check(ver) || b.Check(ver), or.Check(ver), "%s or", v)
+	}
+
+	// The options of the receiver are kept
+	pre, err := NewSpecifiers(">=1.0", WithPreRelease(true))
+	require.NoError(t, err)
+	lt, err := NewSpecifiers("<2.0")
+	require.NoError(t, err)
+	assert.True(t, pre.And(lt).Check(MustParse("2.0a1")))
+	assert.False(t, lt.And(pre).Check(MustParse("2.0a1")))
+}
+
+func TestSpecifiers_Canonical(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		want       string
+	}{
+		{">=1.0", ">=1.0"},
+		{"<2, >= 1.0.0-rc1 || 3", "<2,>=1.0.0rc1||==3"},
+		{"==3||>=1.0.0rc1,<2", "<2,>=1.0.0rc1||==3"},
+		{"=1.0, 1.0, ==1.0", "==1.0"},
+		{"== 1.0.* || ==1.0.*", "==1.0.*"},
+		{"~=1.4.5-1, !=v1.4.7", "!=1.4.7,~=1.4.5.post1"},
+		{"===1.0", "===1.0"},
+		{"*", ">=0.0.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ss.Canonical())
+
+			// The canonical form is stable
+			canonical, err := NewSpecifiers(ss.Canonical())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, canonical.Canonical())
+		})
+	}
+}
+
+func TestSpecifiers_Equal(t *testing.T) {
+	a, err := NewSpecifiers("<2, >= 1.0.0-rc1 || 3")
+	require.NoError(t, err)
+	b, err := NewSpecifiers("==3||>=1.0.0rc1,<2")
+	require.NoError(t, err)
+	c, err := NewSpecifiers(">=1.0.0rc1,<2")
+	require.NoError(t, err)
+	d, err := NewSpecifiers("==3||>=1.0.0rc1,<2", WithPreRelease(true))
+	require.NoError(t, err)
+	e, err := NewSpecifiers("==3||>=1.0.0rc1,<2", WithMaxClauses(10))
+	require.NoError(t, err)
+
+	assert.True(t, a.Equal(b))
+	assert.False(t, a.Equal(c))
+	assert.False(t, a.Equal(d))
+	assert.True(t, a.Equal(e))
+}
+
+func TestSpecifiers_Groups(t *testing.T) {
+	ss, err := NewSpecifiers(">= 1.0, <2.0 || ==3.* || ~=4.1 !=4.1.3")
+	require.NoError(t, err)
+
+	groups := ss.Groups()
+	assert.Equal(t, []Group{
+		{Clauses: []Specifier{{Operator: OpGreaterThanEqual, Version: "1.0"}, {Operator: OpLessThan, Version: "2.0"}}},
+		{Clauses: []Specifier{{Operator: OpEqual, Version: "3.*"}}},
+		{Clauses: []Specifier{{Operator: OpCompatible, Version: "4.1"}, {Operator: OpNotEqual, Version: "4.1.3"}}},
+	}, groups)
+
+	var rendered []string
+	for _, g := range groups {
+		rendered = append(rendered, g.String())
+	}
+	assert.Equal(t, []string{">=1.0,<2.0", "==3.*", "~=4.1,!=4.1.3"}, rendered)
+	assert.Empty(t, Specifiers{}.Groups())
+}
+
+func TestNewSpecifiers_RequireOperator(t *testing.T) {
+	for _, s := range []string{">=1.0, ==2.0", "===1.0", "~=1.0 || !=1.5", "*"} {
+		_, err := NewSpecifiers(s, WithRequireOperator(true))
+		assert.NoError(t, err, s)
+	}
+
+	tests := []struct {
+		specifiers     string
+		wantOffset     int
+		wantSuggestion string
+	}{
+		{"2.0", 0, "==2.0"},
+		{">=1.0, 2.0", 7, ">=1.0, ==2.0"},
+		{">=1.0 || 2.0.*", 9, ">=1.0 || ==2.0.*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			_, err := NewSpecifiers(tt.specifiers)
+			require.NoError(t, err)
+
+			_, err = NewSpecifiers(tt.specifiers, WithRequireOperator(true))
+			var parseErr *ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.ErrorIs(t, err, ErrMissingOperator)
+			assert.ErrorIs(t, err, ErrInvalidSpecifier)
+			assert.Equal(t, tt.wantOffset, parseErr.Offset)
+			assert.Equal(t, tt.wantSuggestion, parseErr.Suggestion)
+		})
+	}
+}
+
+func TestValidateSpecifiers(t *testing.T) {
+	type clauseError struct {
+		offset int
+		token  string
+		err    error
+	}
+	tests := []struct {
+		specifiers string
+		opts       []SpecifierOption
+		want       []clauseError
+	}{
+		{specifiers: ">=1.0, <2.0 || ==3.*"},
+		{specifiers: ">=1.0,"},
+		{specifiers: "*"},
+		{
+			specifiers: ">=1.0, =>1.2, <2.0 || ~=foo",
+			want: []clauseError{
+				{7, "=>1.2", ErrInvalidSpecifier},
+				{22, "~=foo", ErrInvalidSpecifier},
+			},
+		},
+		{
+			specifiers: ",>=1.0,,<2.0",
+			want: []clauseError{
+				{0, "", ErrInvalidSpecifier},
+				{7, "", ErrInvalidSpecifier},
+			},
+		},
+		{
+			specifiers: "~=1.0.*, ==1.0+local.*",
+			want: []clauseError{
+				{0, "~=1.0.*", ErrInvalidSpecifier},
+				{9, "==1.0+local.*", ErrInvalidSpecifier},
+			},
+		},
+		{
+			specifiers: "1.0, >=1.1 || 2.0",
+			opts:       []SpecifierOption{WithRequireOperator(true)},
+			want: []clauseError{
+				{0, "1.0", ErrMissingOperator},
+				{14, "2.0", ErrMissingOperator},
+			},
+		},
+		{
+			specifiers: ">=1.0, <2.0, !=1.5",
+			opts:       []SpecifierOption{WithMaxClauses(2)},
+			want: []clauseError{
+				{13, "!=1.5", ErrTooManyClauses},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			errs := ValidateSpecifiers(tt.specifiers, tt.opts...)
+			_, err := NewSpecifiers(tt.specifiers, tt.opts...)
+			assert.Equal(t, err == nil, len(errs) == 0)
+
+			require.Len(t, errs, len(tt.want))
+			for i, want := range tt.want {
+				var parseErr *ParseError
+				require.ErrorAs(t, errs[i], &parseErr)
+				assert.ErrorIs(t, errs[i], want.err)
+				assert.ErrorIs(t, errs[i], ErrInvalidSpecifier)
+				assert.Equal(t, want.offset, parseErr.Offset)
+				assert.Equal(t, want.token, parseErr.Token)
+			}
+		})
+	}
+
+	t.Run("too long", func(t *testing.T) {
+		errs := ValidateSpecifiers(">=1.0, <2.0", WithMaxSpecifierLength(5))
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], ErrInputTooLong)
+	})
+}
+
+func TestSpecifiers_CheckDetail(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		version    string
+		opts       []SpecifierOption
+		want       bool
+		reasons    []string
+	}{
+		{specifiers: ">=1.0, <2.0", version: "1.5", want: true, reasons: []string{"", ""}},
+		{specifiers: ">=1.0, <2.0", version: "0.9", reasons: []string{"lower than 1.0", ""}},
+		{specifiers: "<2.0", version: "2.0rc1", reasons: []string{"pre-release excluded"}},
+		{specifiers: "<2.0", version: "2.0rc1", opts: []SpecifierOption{WithPreRelease(true)}, want: true, reasons: []string{""}},
+		{specifiers: ">1.0", version: "1.0.post1", reasons: []string{"post-release excluded"}},
+		{specifiers: ">1.0", version: "1.0+local", reasons: []string{"local version excluded"}},
+		{specifiers: ">1.0", version: "0.5", reasons: []string{"not greater than 1.0"}},
+		{specifiers: "~=1.4.5", version: "1.5", reasons: []string{"not a 1.4.* release"}},
+		{specifiers: "~=1.4.5", version: "1.4.1", reasons: []string{"lower than 1.4.5"}},
+		{specifiers: "==1.*, !=1.3", version: "1.3", reasons: []string{"", "equal to 1.3"}},
+		{specifiers: "==1.* || ===2.0", version: "2.0", want: true, reasons: []string{"does not match 1.*", ""}},
+		{specifiers: "==1.* || ===2.0", version: "2.0.0", reasons: []string{"does not match 1.*", "not identical to 2.0"}},
+		{specifiers: "<=1.0 || >=2.0", version: "2.1", want: true, reasons: []string{"greater than 1.0", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers+" "+tt.version, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers, tt.opts...)
+			require.NoError(t, err)
+			v := MustParse(tt.version)
+
+			got, results := ss.CheckDetail(v)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, ss.Check(v), got)
+			require.Len(t, results, len(tt.reasons))
+			for i, r := range results {
+				assert.Equal(t, tt.reasons[i] == "", r.Satisfied)
+				assert.Equal(t, tt.reasons[i], r.Reason)
+			}
+		})
+	}
+
+	ss, err := NewSpecifiers(">=1.0 || == 2.*")
+	require.NoError(t, err)
+	_, results := ss.CheckDetail(MustParse("0.1"))
+	assert.Equal(t, []ClauseResult{
+		{Group: 0, Specifier: Specifier{Operator: OpGreaterThanEqual, Version: "1.0"}, Reason: "lower than 1.0"},
+		{Group: 1, Specifier: Specifier{Operator: OpEqual, Version: "2.*"}, Reason: "does not match 2.*"},
+	}, results)
+}
+
+func TestSpecifiers_Report(t *testing.T) {
+	ss, err := NewSpecifiers(">=1.0,<2.0,!=1.5 || >=1.0,==3.*")
+	require.NoError(t, err)
+	candidates, err := ParseAll([]string{"0.9", "1.0", "1.5", "2.0rc1", "2.0", "3.0"})
+	require.NoError(t, err)
+
+	got := ss.Report(candidates)
+	assert.Equal(t, 2, got.Matched)
+	assert.Equal(t, 4, got.Rejected)
+	assert.Equal(t, map[Specifier]int{
+		{Operator: OpGreaterThanEqual, Version: "1.0"}: 1,
+		{Operator: OpLessThan, Version: "2.0"}:         2,
+		{Operator: OpNotEqual, Version: "1.5"}:         1,
+		{Operator: OpEqual, Version: "3.*"}:            4,
+	}, got.Excluded)
+
+	require.Len(t, got.Results, len(candidates))
+	var excludedBy [][]string
+	for i, r := range got.Results {
+		assert.Equal(t, candidates[i], r.Version)
+		assert.Equal(t, ss.Check(r.Version), r.Matched)
+		var clauses []string
+		for _, c := range r.ExcludedBy {
+			clauses = append(clauses, c.String()+": "+c.Reason)
+		}
+		excludedBy = append(excludedBy, clauses)
+	}
+	assert.Equal(t, [][]string{
+		{">=1.0: lower than 1.0", ">=1.0: lower than 1.0", "==3.*: does not match 3.*"},
+		nil,
+		{"!=1.5: equal to 1.5", "==3.*: does not match 3.*"},
+		{"<2.0: pre-release excluded", "==3.*: does not match 3.*"},
+		{"<2.0: not lower than 2.0", "==3.*: does not match 3.*"},
+		nil,
+	}, excludedBy)
+
+	empty := ss.Report(nil)
+	assert.Empty(t, empty.Results)
+	assert.Zero(t, empty.Matched+empty.Rejected)
+}
+
+func TestSpecifiers_PreReleasePolicy(t *testing.T) {
+	tests := []struct {
+		spec    string
+		version string
+		want    map[PreReleasePolicy]bool
+	}{
+		{">=1.0", "2.0", map[PreReleasePolicy]bool{
+			PreReleaseDefault: true, PreReleaseAllow: true, PreReleaseDeny: true, PreReleaseAuto: true}},
+		{">=1.0", "2.0a1", map[PreReleasePolicy]bool{
+			PreReleaseDefault: true, PreReleaseAllow: true, PreReleaseDeny: false, PreReleaseAuto: false}},
+		{">=1.0", "2.0.dev1", map[PreReleasePolicy]bool{
+			PreReleaseDefault: true, PreReleaseAllow: true, PreReleaseDeny: false, PreReleaseAuto: false}},
+		{"<2.0", "2.0a1", map[PreReleasePolicy]bool{
+			PreReleaseDefault: false, PreReleaseAllow: true, PreReleaseDeny: false, PreReleaseAuto: false}},
+		{">=2.0b1", "2.0b2", map[PreReleasePolicy]bool{
+			PreReleaseDefault: true, PreReleaseAllow: true, PreReleaseDeny: false, PreReleaseAuto: true}},
+		{"==2.0rc1.*", "2.0rc1.post1", map[PreReleasePolicy]bool{
+			PreReleaseDefault: true, PreReleaseAllow: true, PreReleaseDeny: false, PreReleaseAuto: true}},
+		// The exclusive operators don't allow pre-releases even if the spec version is a pre-release
+		{">2.0b1", "2.0b2", map[PreReleasePolicy]bool{
+			PreReleaseDefault: true, PreReleaseAllow: true, PreReleaseDeny: false, PreReleaseAuto: false}},
+		// Any clause of the OR group naming a pre-release allows pre-releases
+		{">=1.0, <=3.0a1", "2.0a1", map[PreReleasePolicy]bool{
+			PreReleaseDefault: true, PreReleaseAllow: true, PreReleaseDeny: false, PreReleaseAuto: true}},
+		// ... but other OR groups don't
+		{">=1.0 || ==0.1a1", "2.0a1", map[PreReleasePolicy]bool{
+			PreReleaseDefault: true, PreReleaseAllow: true, PreReleaseDeny: false, PreReleaseAuto: false}},
+	}
+	for _, tt := range tests {
+		for policy, want := range tt.want {
+			t.Run(fmt.Sprintf("%s %s %d", tt.spec, tt.version, policy), func(t *testing.T) {
+				ss, err := NewSpecifiers(tt.spec, WithPreReleasePolicy(policy))
+				require.NoError(t, err)
+				v := MustParse(tt.version)
+
+				assert.Equal(t, want, ss.Check(v))
+				assert.Equal(t, []bool{want}, ss.CheckAll([]Version{v}))
+				got, _ := ss.CheckDetail(v)
+				assert.Equal(t, want, got)
+			})
+		}
+	}
+
+	// WithPreRelease is the same as PreReleaseAllow
+	a, err := NewSpecifiers("<2.0", WithPreRelease(true))
+	require.NoError(t, err)
+	b, err := NewSpecifiers("<2.0", WithPreReleasePolicy(PreReleaseAllow))
+	require.NoError(t, err)
+	assert.True(t, a.Equal(b))
+
+	ss, err := NewSpecifiers(">=1.0", WithPreReleasePolicy(PreReleaseDeny))
+	require.NoError(t, err)
+	_, results := ss.CheckDetail(MustParse("2.0a1"))
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Satisfied)
+	assert.Equal(t, "pre-release excluded", results[0].Reason)
+}
+
+func TestSpecifiers_Filter(t *testing.T) {
+	tests := []struct {
+		spec     string
+		policy   PreReleasePolicy
+		versions []string
+		want     []string
+	}{
+		{">=1.0, <2.0", PreReleaseDefault, []string{"0.9", "1.0", "1.5a1", "2.0a1", "1.9"}, []string{"1.0", "1.5a1", "1.9"}},
+		{">=1.0, <2.0", PreReleaseAllow, []string{"0.9", "1.0", "1.5a1", "2.0a1", "1.9"}, []string{"1.0", "1.5a1", "2.0a1", "1.9"}},
+		{">=1.0, <2.0", PreReleaseDeny, []string{"0.9", "1.0", "1.5a1", "2.0a1", "1.9"}, []string{"1.0", "1.9"}},
+		{">=1.0, <2.0", PreReleaseAuto, []string{"0.9", "1.0", "1.5a1", "2.0a1", "1.9"}, []string{"1.0", "1.9"}},
+		// Pre-releases are returned if nothing else matches
+		{">=1.0", PreReleaseAuto, []string{"0.9", "2.0a1", "2.0b1"}, []string{"2.0a1", "2.0b1"}},
+		{">=1.0", PreReleaseDeny, []string{"0.9", "2.0a1", "2.0b1"}, nil},
+		{">=1.0b1", PreReleaseAuto, []string{"1.0a1", "1.0b2", "1.0"}, []string{"1.0b2", "1.0"}},
+		{">=3.0", PreReleaseAuto, []string{"1.0", "2.0a1"}, nil},
+		{"*", PreReleaseAuto, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.spec, tt.policy), func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.spec, WithPreReleasePolicy(tt.policy))
+			require.NoError(t, err)
+			versions, err := ParseAll(tt.versions)
+			require.NoError(t, err)
+
+			var got []string
+			for _, v := range ss.Filter(versions) {
+				got = append(got, v.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSpecifiers_Latest(t *testing.T) {
+	tests := []struct {
+		spec       string
+		policy     PreReleasePolicy
+		candidates []string
+		want       string
+		wantOK     bool
+	}{
+		{spec: ">=1.0, <2.0", candidates: []string{"1.0", "1.9", "2.0", "1.5"}, want: "1.9", wantOK: true},
+		{spec: ">=1.0, <2.0", candidates: []string{"1.0", "1.9", "1.9.0"}, want: "1.9", wantOK: true},
+		{spec: ">=1.0, <2.0", candidates: []string{"1.9", "2.0rc1"}, want: "1.9", wantOK: true},
+		{spec: ">=1.0", candidates: []string{"1.9", "2.0rc1"}, want: "2.0rc1", wantOK: true},
+		{spec: ">=1.0", policy: PreReleaseAuto, candidates: []string{"1.9", "2.0rc1"}, want: "1.9", wantOK: true},
+		{spec: ">=1.0", policy: PreReleaseAuto, candidates: []string{"0.9", "2.0rc1", "2.0b1"}, want: "2.0rc1", wantOK: true},
+		{spec: ">=1.0", policy: PreReleaseDeny, candidates: []string{"0.9", "2.0rc1"}},
+		{spec: ">=3.0", candidates: []string{"1.0", "2.0"}},
+		{spec: ">=3.0"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d %v", tt.spec, tt.policy, tt.candidates), func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.spec, WithPreReleasePolicy(tt.policy))
+			require.NoError(t, err)
+			candidates, err := ParseAll(tt.candidates)
+			require.NoError(t, err)
+
+			got, ok := ss.Latest(candidates)
+			require.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, got.Original())
+			}
+		})
+	}
+}
+
+func TestSpecifiers_Earliest(t *testing.T) {
+	tests := []struct {
+		spec       string
+		policy     PreReleasePolicy
+		candidates []string
+		want       string
+		wantOK     bool
+	}{
+		{spec: ">=1.0, <2.0", candidates: []string{"1.5", "1.0.0", "1.0", "0.9"}, want: "1.0.0", wantOK: true},
+		{spec: ">=1.0", candidates: []string{"1.1", "1.1a1"}, want: "1.1a1", wantOK: true},
+		{spec: ">=1.0", policy: PreReleaseAuto, candidates: []string{"1.1", "1.1a1"}, want: "1.1", wantOK: true},
+		{spec: ">=1.0", policy: PreReleaseAuto, candidates: []string{"0.9", "1.1a2", "1.1a1"}, want: "1.1a1", wantOK: true},
+		{spec: ">=3.0", candidates: []string{"1.0", "2.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d %v", tt.spec, tt.policy, tt.candidates), func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.spec, WithPreReleasePolicy(tt.policy))
+			require.NoError(t, err)
+			candidates, err := ParseAll(tt.candidates)
+			require.NoError(t, err)
+
+			got, ok := ss.Earliest(candidates)
+			require.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, got.Original())
+			}
+		})
+	}
+}
+
+func TestSpecifiers_AllowsUpgrade(t *testing.T) {
+	tests := []struct {
+		spec      string
+		policy    PreReleasePolicy
+		current   string
+		candidate string
+		want      bool
+	}{
+		{spec: ">=1.0, <2.0", current: "1.4", candidate: "1.5", want: true},
+		{spec: ">=1.0, <2.0", current: "1.4", candidate: "2.0"},
+		{spec: ">=1.0, <2.0", current: "1.4", candidate: "1.3"},
+		{spec: ">=1.0, <2.0", current: "1.4", candidate: "1.4.0"},
+		{spec: ">=1.0, <2.0", current: "0.9", candidate: "1.0", want: true},
+		{spec: ">=1.0, <2.0", current: "1.4", candidate: "1.5rc1", want: true},
+		{spec: ">=1.0, <2.0", policy: PreReleaseDeny, current: "1.4", candidate: "1.5rc1"},
+		{spec: "~=1.4 || ==3.*", current: "1.9", candidate: "3.1", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d %s to %s", tt.spec, tt.policy, tt.current, tt.candidate), func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.spec, WithPreReleasePolicy(tt.policy))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ss.AllowsUpgrade(MustParse(tt.current), MustParse(tt.candidate)))
+		})
+	}
+}
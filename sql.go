@@ -0,0 +1,63 @@
+package version
+
+import (
+	"database/sql/driver"
+
+	"golang.org/x/xerrors"
+)
+
+// Scan implements [database/sql.Scanner]. NULL is scanned into the zero Version.
+func (v *Version) Scan(src any) error {
+	switch s := src.(type) {
+	case nil:
+		*v = Version{}
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(s))
+	case []byte:
+		return v.UnmarshalText(s)
+	}
+	return xerrors.Errorf("unable to scan %T into Version", src)
+}
+
+// Value implements [database/sql/driver.Valuer]. The zero Version is stored as NULL.
+func (v Version) Value() (driver.Value, error) {
+	if v.infinity != 0 {
+		return nil, xerrors.Errorf("storing %s: %w", v, ErrInvalidVersion)
+	}
+	if s := v.String(); s != "" {
+		return s, nil
+	}
+	return nil, nil
+}
+
+// Scan implements [database/sql.Scanner]. NULL is scanned into the zero Specifiers.
+func (ss *Specifiers) Scan(src any) error {
+	var s string
+	switch t := src.(type) {
+	case nil:
+		*ss = Specifiers{}
+		return nil
+	case string:
+		s = t
+	case []byte:
+		s = string(t)
+	default:
+		return xerrors.Errorf("unable to scan %T into Specifiers", src)
+	}
+
+	specifiers, err := NewSpecifiers(s)
+	if err != nil {
+		return err
+	}
+	*ss = specifiers
+	return nil
+}
+
+// Value implements [database/sql/driver.Valuer]. The zero Specifiers is stored as NULL.
+func (ss Specifiers) Value() (driver.Value, error) {
+	if s := ss.String(); s != "" {
+		return s, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,76 @@
+package version_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+var (
+	_ sql.Scanner   = (*version.Version)(nil)
+	_ driver.Valuer = version.Version{}
+	_ sql.Scanner   = (*version.Specifiers)(nil)
+	_ driver.Valuer = version.Specifiers{}
+)
+
+func TestVersion_SQL(t *testing.T) {
+	t.Run("Scan", func(t *testing.T) {
+		for _, src := range []any{"1.0.post456.dev34", []byte("1.0.post456.dev34")} {
+			var got version.Version
+			require.NoError(t, got.Scan(src))
+			assert.Equal(t, version.MustParse("1.0.post456.dev34"), got)
+		}
+
+		got := version.MustParse("1.0")
+		require.NoError(t, got.Scan(nil))
+		assert.Equal(t, version.Version{}, got)
+
+		assert.ErrorIs(t, got.Scan("french toast"), version.ErrInvalidVersion)
+		assert.Error(t, got.Scan(42))
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		got, err := version.MustParse("v1.0-1").Value()
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.post1", got)
+
+		got, err = version.Version{}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestSpecifiers_SQL(t *testing.T) {
+	t.Run("Scan", func(t *testing.T) {
+		for _, src := range []any{">=1.0, <2.0 || ==3.0", []byte(">=1.0, <2.0 || ==3.0")} {
+			var got version.Specifiers
+			require.NoError(t, got.Scan(src))
+			assert.Equal(t, ">=1.0,<2.0||==3.0", got.String())
+		}
+
+		var got version.Specifiers
+		require.NoError(t, got.Scan(nil))
+		assert.Equal(t, version.Specifiers{}, got)
+
+		assert.ErrorIs(t, got.Scan("=>1.0"), version.ErrInvalidSpecifier)
+		assert.Error(t, got.Scan(42))
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		ss, err := version.NewSpecifiers(">=1.0, <2.0")
+		require.NoError(t, err)
+
+		got, err := ss.Value()
+		require.NoError(t, err)
+		assert.Equal(t, ">=1.0,<2.0", got)
+
+		got, err = version.Specifiers{}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
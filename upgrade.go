@@ -0,0 +1,65 @@
+package version
+
+// UpgradeKind is the kind of an upgrade from one version to another, as classified by ClassifyUpgrade.
+type UpgradeKind int
+
+const (
+	// UpgradeNone means the new version isn't greater than the old one.
+	UpgradeNone UpgradeKind = iota
+	// UpgradeMajor means the epoch or the first component of the release segment changed, e.g. 1.4 to 2.0.
+	UpgradeMajor
+	// UpgradeMinor means the second component of the release segment changed, e.g. 1.4 to 1.5.
+	UpgradeMinor
+	// UpgradePatch means a later component of the release segment changed, or only the qualifiers of a release
+	// which isn't a pre-release, e.g. 1.4 to 1.4.1, 1.4.1 to 1.4.1.post1 or 1.5rc1 to 1.5.
+	UpgradePatch
+	// UpgradePreRelease means the new version is a pre-release or a development release, e.g. 1.4 to 2.0b1.
+	UpgradePreRelease
+)
+
+// String returns the name of the kind, e.g. "minor".
+func (k UpgradeKind) String() string {
+	switch k {
+	case UpgradeMajor:
+		return "major"
+	case UpgradeMinor:
+		return "minor"
+	case UpgradePatch:
+		return "patch"
+	case UpgradePreRelease:
+		return "prerelease"
+	}
+	return "none"
+}
+
+// ClassifyUpgrade classifies the upgrade from one version to another like semver does, by the first component of
+// the release segment which changed, where missing components are 0, e.g. 1.4 to 1.4.0.1 is a patch upgrade.
+// An upgrade to a pre-release or a development release is UpgradePreRelease whichever component changed, so that
+// tooling can treat them apart, and a downgrade or the same version is UpgradeNone.
+func ClassifyUpgrade(from, to Version) UpgradeKind {
+	switch {
+	case !to.GreaterThan(from):
+		return UpgradeNone
+	case to.IsPreRelease():
+		return UpgradePreRelease
+	case to.epoch != from.epoch || to.Major() != from.Major():
+		return UpgradeMajor
+	case to.Minor() != from.Minor():
+		return UpgradeMinor
+	}
+	return UpgradePatch
+}
+
+// MinimalUpgrade returns the smallest of the candidates which is greater than or equal to the current version and
+// satisfies the target specifiers, e.g. when tightening a constraint after a vulnerability is published. It is the
+// current version itself if it is one of the candidates and satisfies them. It honors the pre-release policy of
+// the target like Specifiers.Earliest, and returns false if no candidate qualifies.
+func MinimalUpgrade(current Version, target Specifiers, candidates []Version) (Version, bool) {
+	var above []Version
+	for _, c := range candidates {
+		if c.GreaterThanOrEqual(current) {
+			above = append(above, c)
+		}
+	}
+	return target.Earliest(above)
+}
@@ -0,0 +1,76 @@
+package version
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyUpgrade(t *testing.T) {
+	tests := []struct {
+		from string
+		to   string
+		want UpgradeKind
+	}{
+		{from: "1.4", to: "2.0", want: UpgradeMajor},
+		{from: "1.4.2", to: "3", want: UpgradeMajor},
+		{from: "2.0", to: "1!1.0", want: UpgradeMajor},
+		{from: "1.4", to: "1.5", want: UpgradeMinor},
+		{from: "1.4.9", to: "1.10", want: UpgradeMinor},
+		{from: "1", to: "1.1", want: UpgradeMinor},
+		{from: "1.4", to: "1.4.1", want: UpgradePatch},
+		{from: "1.4", to: "1.4.0.1", want: UpgradePatch},
+		{from: "1.4.1", to: "1.4.1.post1", want: UpgradePatch},
+		{from: "1.5rc1", to: "1.5", want: UpgradePatch},
+		{from: "1.5", to: "1.5+ubuntu1", want: UpgradePatch},
+		{from: "1.4", to: "2.0b1", want: UpgradePreRelease},
+		{from: "1.5rc1", to: "1.5rc2", want: UpgradePreRelease},
+		{from: "1.4", to: "1.4.1.dev0", want: UpgradePreRelease},
+		{from: "1.4", to: "1.4.0", want: UpgradeNone},
+		{from: "1.4", to: "1.3", want: UpgradeNone},
+		{from: "1.4", to: "1.4rc1", want: UpgradeNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			got := ClassifyUpgrade(MustParse(tt.from), MustParse(tt.to))
+			assert.Equal(t, tt.want, got, got.String())
+		})
+	}
+}
+
+func TestMinimalUpgrade(t *testing.T) {
+	candidates := []string{"1.0", "1.2", "1.2.5rc1", "1.2.5", "1.3", "2.0a1", "2.0", "2.1"}
+	tests := []struct {
+		current string
+		target  string
+		policy  PreReleasePolicy
+		want    string
+		wantOK  bool
+	}{
+		{current: "1.2", target: ">=1.2.5", want: "1.2.5", wantOK: true},
+		{current: "1.2", target: ">=1.2.5rc1", want: "1.2.5rc1", wantOK: true},
+		{current: "1.2", target: ">=1.2.5rc1", policy: PreReleaseDeny, want: "1.2.5", wantOK: true},
+		{current: "1.3", target: ">=1.2.5", want: "1.3", wantOK: true},
+		{current: "1.3", target: "<1.2.5 || >=2.0", want: "2.0", wantOK: true},
+		{current: "1.1", target: "!=1.2", want: "1.2.5rc1", wantOK: true},
+		{current: "1.1", target: "!=1.2", policy: PreReleaseAuto, want: "1.2.5", wantOK: true},
+		{current: "2.1", target: "<2.0"},
+		{current: "3.0", target: ">=1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s %d", tt.current, tt.target, tt.policy), func(t *testing.T) {
+			target, err := NewSpecifiers(tt.target, WithPreReleasePolicy(tt.policy))
+			require.NoError(t, err)
+			versions, err := ParseAll(candidates)
+			require.NoError(t, err)
+
+			got, ok := MinimalUpgrade(MustParse(tt.current), target, versions)
+			require.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
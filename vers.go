@@ -0,0 +1,198 @@
+package version
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// versPrefix starts a VERS range of the pypi versioning scheme.
+const versPrefix = "vers:pypi/"
+
+// versComparators are the comparators of VERS constraints, longest first.
+var versComparators = []string{">=", "<=", "!=", "<", ">", "="}
+
+// versConstraint is a constraint of a VERS range, e.g. ">=1.0".
+type versConstraint struct {
+	comparator string
+	version    Version
+}
+
+// ParseVERS parses a range of the VERS specification for the pypi scheme, e.g. "vers:pypi/>=1.0|<2.0|!=1.5", as used
+// with package URLs. VERS compares versions in their order, so "<2.0" includes the pre-releases of 2.0 and ">1.0" the
+// post-releases of 1.0, and the result is written like FromRanges, e.g. ">=1.0,<2.0,!=1.5" with PreReleaseAllow.
+// A range of != constraints only is satisfied by every other version. It returns ErrInvalidSpecifier if the range is
+// malformed or of another scheme, ErrInvalidVersion for a version which isn't valid, and the error of FromRanges if
+// the versions can't be written as specifiers.
+func ParseVERS(s string) (Specifiers, error) {
+	constraints, err := parseVERSConstraints(s)
+	if err != nil {
+		return Specifiers{}, xerrors.Errorf("VERS %q: %w", s, err)
+	}
+
+	// The range constraints alternate between lower bounds (> and >=) and upper bounds (< and <=)
+	var intervals []Interval
+	var excluded []Version
+	lower := Bound{Unbounded: true}
+	for i, c := range constraints {
+		switch c.comparator {
+		case "*":
+			intervals = append(intervals, Interval{Lower: Bound{Unbounded: true}, Upper: Bound{Unbounded: true}})
+		case "=":
+			intervals = append(intervals, Interval{
+				Lower: Bound{Version: c.version, Inclusive: true},
+				Upper: Bound{Version: c.version, Inclusive: true},
+			})
+		case "!=":
+			excluded = append(excluded, c.version)
+		case ">", ">=":
+			if !lower.Unbounded {
+				return Specifiers{}, xerrors.Errorf("VERS %q: %s%s after a lower bound: %w",
+					s, c.comparator, c.version, ErrInvalidSpecifier)
+			}
+			lower = Bound{Version: c.version, Inclusive: c.comparator == ">="}
+			if !slices.ContainsFunc(constraints[i+1:], versConstraint.upper) {
+				intervals = append(intervals, Interval{Lower: lower, Upper: Bound{Unbounded: true}})
+			}
+		case "<", "<=":
+			if lower.Unbounded && slices.ContainsFunc(constraints[:i], versConstraint.upper) {
+				return Specifiers{}, xerrors.Errorf("VERS %q: %s%s after an upper bound: %w",
+					s, c.comparator, c.version, ErrInvalidSpecifier)
+			}
+			intervals = append(intervals, Interval{
+				Lower: lower,
+				Upper: Bound{Version: c.version, Inclusive: c.comparator == "<="},
+			})
+			lower = Bound{Unbounded: true}
+		}
+	}
+	if len(intervals) == 0 {
+		intervals = append(intervals, Interval{Lower: Bound{Unbounded: true}, Upper: Bound{Unbounded: true}})
+	}
+
+	for _, v := range excluded {
+		var split []Interval
+		for _, i := range intervals {
+			if !i.Contains(v) {
+				split = append(split, i)
+				continue
+			}
+			split = append(split,
+				Interval{Lower: i.Lower, Upper: Bound{Version: v}},
+				Interval{Lower: Bound{Version: v}, Upper: i.Upper})
+		}
+		intervals = split
+	}
+
+	ss, err := FromRanges(intervals)
+	if err != nil {
+		return Specifiers{}, xerrors.Errorf("VERS %q: %w", s, err)
+	}
+	return ss, nil
+}
+
+// parseVERSConstraints returns the constraints of a VERS range sorted by their versions.
+func parseVERSConstraints(s string) ([]versConstraint, error) {
+	// Whitespace is insignificant in VERS
+	s = strings.Join(strings.Fields(s), "")
+	rest, ok := strings.CutPrefix(s, versPrefix)
+	if !ok {
+		return nil, xerrors.Errorf("not a pypi range: %w", ErrInvalidSpecifier)
+	} else if rest == "*" {
+		return []versConstraint{{comparator: "*"}}, nil
+	}
+
+	var constraints []versConstraint
+	for _, c := range strings.Split(rest, "|") {
+		comparator := "="
+		if i := slices.IndexFunc(versComparators, func(op string) bool {
+			return strings.HasPrefix(c, op)
+		}); i >= 0 {
+			comparator = versComparators[i]
+		}
+		raw, err := url.PathUnescape(strings.TrimPrefix(c, comparator))
+		if err != nil {
+			return nil, xerrors.Errorf("constraint %q: %w", c, ErrInvalidSpecifier)
+		} else if raw == "" {
+			return nil, xerrors.Errorf("constraint %q without a version: %w", c, ErrInvalidSpecifier)
+		}
+		v, err := Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, versConstraint{comparator: comparator, version: v})
+	}
+
+	slices.SortStableFunc(constraints, func(a, b versConstraint) int {
+		return a.version.Compare(b.version)
+	})
+	for i := 1; i < len(constraints); i++ {
+		if constraints[i].version.Equal(constraints[i-1].version) {
+			return nil, xerrors.Errorf("version %s in several constraints: %w", constraints[i].version, ErrInvalidSpecifier)
+		}
+	}
+	return constraints, nil
+}
+
+// upper reports whether the constraint is an upper bound.
+func (c versConstraint) upper() bool {
+	return c.comparator == "<" || c.comparator == "<="
+}
+
+// VERS returns the versions satisfying the specifiers as a range of the VERS specification for the pypi scheme,
+// e.g. "vers:pypi/>=1.0|!=1.5|<2.0" for ">=1.0,<2.0,!=1.5" with PreReleaseAllow. Like Events, the local versions
+// of a version are treated like it, e.g. "<=2.0" includes 2.0+local. It returns ErrUnrepresentable like Events,
+// e.g. for ">2.0" which excludes the post-releases of 2.0, and for no versions.
+func (ss Specifiers) VERS() (string, error) {
+	switch {
+	case ss.conf.preRelease == PreReleaseDeny || ss.conf.preRelease == PreReleaseAuto:
+		return "", xerrors.Errorf("VERS of %q with the pre-release policy %d: %w",
+			ss, ss.conf.preRelease, ErrUnrepresentable)
+	case slices.ContainsFunc(slices.Concat(ss.specifiers...), ss.conf.inexact):
+		return "", xerrors.Errorf("VERS of %q: %w", ss, ErrUnrepresentable)
+	}
+
+	spans := ss.spans()
+	if len(spans) == 0 {
+		return "", xerrors.Errorf("VERS of %q: no versions: %w", ss, ErrUnrepresentable)
+	} else if len(spans) == 1 && spans[0].lo.lowest() && spans[0].hi.kind == aboveAll {
+		return versPrefix + "*", nil
+	}
+
+	var constraints []string
+	for i, s := range spans {
+		if s.lo.kind == beforeVersion && (s.hi.kind == afterVersion || s.hi.kind == afterLocals) && s.lo.v.Equal(s.hi.v) {
+			constraints = append(constraints, "="+s.lo.v.String())
+			continue
+		}
+
+		// A gap of a single version is written as !=
+		if prev := spans[max(i-1, 0)].hi; i > 0 && (s.lo.kind == afterVersion || s.lo.kind == afterLocals) &&
+			prev.kind == beforeVersion && s.lo.v.Equal(prev.v) {
+			constraints[len(constraints)-1] = "!=" + s.lo.v.String()
+		} else {
+			switch s.lo.kind {
+			case belowAll:
+			case beforeVersion:
+				constraints = append(constraints, ">="+s.lo.v.String())
+			case afterVersion, afterLocals:
+				constraints = append(constraints, ">"+s.lo.v.String())
+			default:
+				return "", xerrors.Errorf("VERS of %q: no smallest version in %s: %w", ss, s.interval(), ErrUnrepresentable)
+			}
+		}
+
+		switch s.hi.kind {
+		case aboveAll:
+		case beforeVersion:
+			constraints = append(constraints, "<"+s.hi.v.String())
+		case afterVersion, afterLocals:
+			constraints = append(constraints, "<="+s.hi.v.String())
+		default:
+			return "", xerrors.Errorf("VERS of %q: no largest version in %s: %w", ss, s.interval(), ErrUnrepresentable)
+		}
+	}
+	return versPrefix + strings.Join(constraints, "|"), nil
+}
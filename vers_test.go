@@ -0,0 +1,93 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVERS(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr error
+	}{
+		{input: "vers:pypi/>=1.0|<2.0", want: ">=1.0,<2.0"},
+		{input: "vers:pypi/<2.0|>=1.0|!=1.5", want: ">=1.0,<2.0,!=1.5"},
+		{input: "vers:pypi/1.3", want: "==1.3"},
+		{input: "vers:pypi/=1.0|>=1.4|<1.5", want: "==1.0||>=1.4,<1.5"},
+		{input: "vers:pypi/<1.2|>=2.0|<=2.3", want: "<1.2||>=2.0,<=2.3"},
+		{input: "vers:pypi/>1.0.post1", want: ">=1.0.post2.dev0"},
+		{input: "vers: pypi/ >= 1.0 | < 2.0rc1", want: ">=1.0,<2.0rc1"},
+		{input: "vers:pypi/*", want: ">=0.dev0"},
+		{input: "vers:pypi/!=1.5", want: "!=1.5"},
+		{input: "vers:pypi/1.0%2Bubuntu1|2.0", want: "==1.0+ubuntu1||==2.0"},
+		{input: "vers:npm/>=1.0", wantErr: ErrInvalidSpecifier},
+		{input: "vers:pypi/>=1.0|>=1.5", wantErr: ErrInvalidSpecifier},
+		{input: "vers:pypi/<1.0|<1.5", wantErr: ErrInvalidSpecifier},
+		{input: "vers:pypi/>=1.0|<=1.0", wantErr: ErrInvalidSpecifier},
+		{input: "vers:pypi/>=", wantErr: ErrInvalidSpecifier},
+		{input: "vers:pypi/>=1.x", wantErr: ErrInvalidVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseVERS(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+
+	ss, err := ParseVERS("vers:pypi/>1.0|<2.0")
+	require.NoError(t, err)
+	for v, want := range map[string]bool{"1.0": false, "1.0.post1": true, "2.0rc1": true, "2.0": false} {
+		assert.Equal(t, want, ss.Check(MustParse(v)), v)
+	}
+}
+
+func TestSpecifiers_VERS(t *testing.T) {
+	tests := []struct {
+		specifiers string
+		policy     PreReleasePolicy
+		want       string
+		wantErr    error
+	}{
+		{specifiers: ">=1.0,<2.0,!=1.5", policy: PreReleaseAllow, want: "vers:pypi/>=1.0|!=1.5|<2.0"},
+		{specifiers: ">=1.0,<2.0rc1", want: "vers:pypi/>=1.0|<2.0rc1"},
+		{specifiers: "==1.3", want: "vers:pypi/=1.3"},
+		{specifiers: "==1.0||>=1.4,<=1.5", want: "vers:pypi/=1.0|>=1.4|<=1.5"},
+		{specifiers: "==1.*", want: "vers:pypi/>=1.dev0|<2.dev0"},
+		{specifiers: ">=1.0.post2", want: "vers:pypi/>=1.0.post2"},
+		{specifiers: ">=0.dev0", want: "vers:pypi/*"},
+		{specifiers: ">2.0", wantErr: ErrUnrepresentable},
+		// >1.0.post1 rejects 1.0.post2+local
+		{specifiers: ">1.0.post1", wantErr: ErrUnrepresentable},
+		{specifiers: ">=2.0,<1.0", wantErr: ErrUnrepresentable},
+		{specifiers: "===1.0", wantErr: ErrUnrepresentable},
+		{specifiers: "<2.0", policy: PreReleaseDeny, wantErr: ErrUnrepresentable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.specifiers, func(t *testing.T) {
+			ss, err := NewSpecifiers(tt.specifiers, WithPreReleasePolicy(tt.policy))
+			require.NoError(t, err)
+
+			got, err := ss.VERS()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			parsed, err := ParseVERS(got)
+			require.NoError(t, err)
+			for _, v := range []string{"0.9", "1.0", "1.0.post1", "1.0.post2", "1.3", "1.4", "1.5rc1", "1.5", "1.5.1", "2.0rc1", "2.0"} {
+				assert.Equal(t, ss.Check(MustParse(v)), parsed.Check(MustParse(v)), "%s as %s: %s", ss, parsed, v)
+			}
+		})
+	}
+}
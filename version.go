@@ -2,9 +2,15 @@ package version
 
 import (
 	"bytes"
+	"cmp"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/xerrors"
 
@@ -12,26 +18,41 @@ import (
 )
 
 var (
+	// The regular expressions are compiled on first use, so that importers which never parse don't pay for them.
+
 	// The compiled regular expression used to test the validity of a version.
-	versionRegex *regexp.Regexp
+	// Parse uses the hand-written parser instead. This is kept as the reference for its tests.
+	versionRegex = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`(?i)^\s*` + regex + `\s*$`)
+	})
+
+	// The compiled regular expression used to find the longest valid prefix of a malformed version.
+	versionPrefixRegex = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`(?i)^\s*` + regex)
+	})
+
+	// The compiled regular expression used to test the validity of a local version segment.
+	localRegex = sync.OnceValue(func() *regexp.Regexp {
+		return regexp.MustCompile(`(?i)^[a-z0-9]+(?:[-_\.][a-z0-9]+)*$`)
+	})
 
 	// https://github.com/pypa/packaging/blob/a6407e3a7e19bd979e93f58cfc7f6641a7378c46/packaging/version.py#L459-L464
-	preReleaseAliases = map[string]string{
-		"a":       "a",
-		"alpha":   "a",
-		"b":       "b",
-		"beta":    "b",
-		"rc":      "rc",
-		"c":       "rc",
-		"pre":     "rc",
-		"preview": "rc",
+	preReleaseAliases = map[string]qualifier{
+		"a":       alphaQualifier,
+		"alpha":   alphaQualifier,
+		"b":       betaQualifier,
+		"beta":    betaQualifier,
+		"rc":      rcQualifier,
+		"c":       rcQualifier,
+		"pre":     rcQualifier,
+		"preview": rcQualifier,
 	}
 
 	// https://github.com/pypa/packaging/blob/a6407e3a7e19bd979e93f58cfc7f6641a7378c46/packaging/version.py#L465-L466
-	postReleaseAliases = map[string]string{
-		"post": "post",
-		"rev":  "post",
-		"r":    "post",
+	postReleaseAliases = map[string]qualifier{
+		"post": postQualifier,
+		"rev":  postQualifier,
+		"r":    postQualifier,
 	}
 )
 
@@ -47,6 +68,35 @@ const (
 		`(?:\+(?P<local>[a-z0-9]+(?:[-_\.][a-z0-9]+)*))?` // local version`
 )
 
+// PreReleasePhase represents the phase of a pre-release.
+type PreReleasePhase int
+
+const (
+	NoPreRelease PreReleasePhase = iota
+	Alpha
+	Beta
+	ReleaseCandidate
+)
+
+var preReleasePhases = map[qualifier]PreReleasePhase{
+	alphaQualifier: Alpha,
+	betaQualifier:  Beta,
+	rcQualifier:    ReleaseCandidate,
+}
+
+// String returns the normalized letter of the phase
+func (p PreReleasePhase) String() string {
+	switch p {
+	case Alpha:
+		return "a"
+	case Beta:
+		return "b"
+	case ReleaseCandidate:
+		return "rc"
+	}
+	return ""
+}
+
 // Version represents a single version.
 type Version struct {
 	epoch              part.Uint64
@@ -55,37 +105,56 @@ type Version struct {
 	post               letterNumber
 	dev                letterNumber
 	local              string
-	key                key
 	preReleaseIncluded bool
 	original           string
+	infinity           int8 // -1 for NegInf and 1 for Inf
 }
 
-type key struct {
-	epoch   part.Uint64
-	release part.Parts
-	pre     part.Part
-	post    part.Part
-	dev     part.Part
-	local   part.Part
-}
+var (
+	// NegInf is lower than every version, e.g. to bound an interval open below. Like Inf, it isn't a PEP 440
+	// version, so it satisfies no specifiers and can't be marshaled.
+	NegInf = Version{infinity: -1}
+
+	// Inf is greater than every version, e.g. to bound an interval open above.
+	// Like the zero Version, NegInf and Inf have no release segment, and the methods deriving a version from one,
+	// like NextMajor, NextPost, Successor and Truncate, return them as is.
+	Inf = Version{infinity: 1}
+)
+
+// qualifier is the interned normalized letter of a pre, post or development release segment,
+// so that versions don't hold strings for them.
+type qualifier uint8
 
-func (k key) compare(o key) int {
-	p1 := part.Parts{k.epoch, k.release, k.pre, k.post, k.dev, k.local}
-	p2 := part.Parts{o.epoch, o.release, o.pre, o.post, o.dev, o.local}
-	return p1.Compare(p2)
+// The pre-release qualifiers sort in the same order as their letters.
+const (
+	noQualifier qualifier = iota
+	alphaQualifier
+	betaQualifier
+	rcQualifier
+	postQualifier
+	devQualifier
+)
+
+var qualifierLetters = [...]string{"", "a", "b", "rc", "post", "dev"}
+
+func (q qualifier) String() string {
+	return qualifierLetters[q]
 }
 
 type letterNumber struct {
-	letter part.String
 	number part.Uint64
+	letter qualifier
 }
 
 func (ln letterNumber) isNull() bool {
-	return ln.letter.IsNull() && ln.number.IsNull()
+	return ln.letter == noQualifier && ln.number == 0
 }
 
-func init() {
-	versionRegex = regexp.MustCompile(`(?i)^\s*` + regex + `\s*$`)
+func (ln letterNumber) compare(o letterNumber) int {
+	if c := cmp.Compare(ln.letter, o.letter); c != 0 {
+		return c
+	}
+	return cmp.Compare(ln.number, o.number)
 }
 
 // MustParse is like Parse but panics if the version cannot be parsed.
@@ -99,57 +168,76 @@ func MustParse(v string) Version {
 
 // Parse parses the given version and returns a new Version.
 func Parse(v string) (Version, error) {
-	matches := versionRegex.FindStringSubmatch(v)
-	if matches == nil {
-		return Version{}, xerrors.Errorf("malformed version: %s", v)
+	m, ok := matchVersion(v)
+	if !ok {
+		err := newParseError(v, versionPrefixRegex(), ErrInvalidVersion)
+		err.Suggestion = suggestVersion(v)
+		return Version{}, err
 	}
 
 	var epoch, preN, postN, devN part.Uint64
-	var preL, postL, devL part.String
-	var release []part.Uint64
+	var preL, postL, devL qualifier
 	var local string
-	var err error
 
-	for i, name := range versionRegex.SubexpNames() {
-		m := matches[i]
-		if m == "" {
-			continue
+	number := func(s span) (part.Uint64, error) {
+		if !s.present() {
+			return 0, nil
 		}
-
-		switch name {
-		case "epoch":
-			epoch, err = part.NewUint64(m)
-		case "release":
-			for _, str := range strings.Split(m, ".") {
-				val, err := part.NewUint64(str)
-				if err != nil {
-					return Version{}, xerrors.Errorf("error parsing version: %w", err)
-				}
-
-				release = append(release, val)
-			}
-		case "pre_l":
-			preL = part.String(preReleaseAliases[strings.ToLower(m)])
-		case "pre_n":
-			preN, err = part.NewUint64(m)
-		case "post_l":
-			postL = part.String(postReleaseAliases[strings.ToLower(m)])
-		case "post_n1", "post_n2":
-			// https://github.com/pypa/packaging/blob/a6407e3a7e19bd979e93f58cfc7f6641a7378c46/packaging/version.py#L469-L472
-			if postL == "" {
-				postL = "post"
-			}
-			postN, err = part.NewUint64(m)
-		case "dev_l":
-			devL = part.String(strings.ToLower(m))
-		case "dev_n":
-			devN, err = part.NewUint64(m)
-		case "local":
-			local = strings.ToLower(m)
+		n, err := part.NewUint64(v[s.start:s.end])
+		if err != nil {
+			return 0, numberError(v, s.start, v[s.start:s.end], err)
 		}
+		return n, nil
+	}
+
+	var err error
+	if epoch, err = number(m.epoch); err != nil {
+		return Version{}, err
+	}
+
+	// Allocate the release segment with the exact size as versions may be held in large numbers.
+	releaseStr := v[m.release.start:m.release.end]
+	release := make([]part.Uint64, 0, strings.Count(releaseStr, ".")+1)
+	start := m.release.start
+	for len(release) < cap(release) {
+		str, _, _ := strings.Cut(v[start:m.release.end], ".")
+		val, err := number(span{start, start + len(str)})
 		if err != nil {
-			return Version{}, xerrors.Errorf("failed to parse version (%s): %w", v, err)
+			return Version{}, err
 		}
+		release = append(release, val)
+		start += len(str) + 1
+	}
+
+	if m.preL.present() {
+		preL = preReleaseAliases[strings.ToLower(v[m.preL.start:m.preL.end])]
+	}
+	if preN, err = number(m.preN); err != nil {
+		return Version{}, err
+	}
+
+	// https://github.com/pypa/packaging/blob/a6407e3a7e19bd979e93f58cfc7f6641a7378c46/packaging/version.py#L469-L472
+	switch {
+	case m.postN1.present():
+		postL = postQualifier
+		postN, err = number(m.postN1)
+	case m.postL.present():
+		postL = postReleaseAliases[strings.ToLower(v[m.postL.start:m.postL.end])]
+		postN, err = number(m.postN2)
+	}
+	if err != nil {
+		return Version{}, err
+	}
+
+	if m.devL.present() {
+		devL = devQualifier
+	}
+	if devN, err = number(m.devN); err != nil {
+		return Version{}, err
+	}
+
+	if m.local.present() {
+		local = strings.ToLower(v[m.local.start:m.local.end])
 	}
 
 	pre := letterNumber{
@@ -165,16 +253,152 @@ func Parse(v string) (Version, error) {
 		number: devN,
 	}
 
+	ver := newVersion(epoch, release, pre, post, dev, local)
+	ver.original = v
+
+	return ver, nil
+}
+
+// ParseWith is like Parse, with the parsing adjusted by the given options.
+func ParseWith(v string, opts ...ParseOption) (Version, error) {
+	c := new(parseConf)
+
+	// Apply options
+	for _, o := range opts {
+		o.apply(c)
+	}
+
+	if c.maxLength > 0 && len(v) > c.maxLength {
+		return Version{}, xerrors.Errorf("version of %d bytes exceeds the maximum of %d: %w",
+			len(v), c.maxLength, ErrInputTooLong)
+	}
+
+	ver, err := Parse(v)
+	if err != nil {
+		return Version{}, err
+	}
+
+	if c.strict {
+		if canonical := ver.String(); v != canonical {
+			var offset int
+			for offset < len(v) && offset < len(canonical) && v[offset] == canonical[offset] {
+				offset++
+			}
+			return Version{}, &ParseError{
+				Input:      v,
+				Offset:     offset,
+				Token:      v[offset:],
+				Err:        ErrNotCanonical,
+				Suggestion: canonical,
+			}
+		}
+	}
+
+	return ver, nil
+}
+
+// IsValid reports whether the given version is a valid PEP 440 version, that is, whether Parse would succeed.
+// Unlike Parse, it does not allocate.
+func IsValid(v string) bool {
+	_, ok := matchVersion(v)
+	return ok && numbersFitUint64(v)
+}
+
+// numbersFitUint64 reports whether all the numbers in the public version fit in uint64.
+func numbersFitUint64(v string) bool {
+	var n uint64
+	var inNumber bool
+	for i := 0; i < len(v) && v[i] != '+'; i++ {
+		c := v[i]
+		if !isDigit(c) {
+			n, inNumber = 0, false
+			continue
+		}
+
+		d := uint64(c - '0')
+		if inNumber && n > (math.MaxUint64-d)/10 {
+			return false
+		}
+		n, inNumber = n*10+d, true
+	}
+	return true
+}
+
+// Canonicalize normalizes the given version and removes trailing zeros from its release segment
+// like packaging.utils.canonicalize_version (e.g. v1.0.0-RC1 -> 1rc1). The result is the same as Key.
+func Canonicalize(s string) (string, error) {
+	v, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return v.Key(), nil
+}
+
+// ParseAll parses all the given versions. It returns the successfully parsed versions in order,
+// and an error joining the failures, each annotated with its index in vs.
+func ParseAll(vs []string) ([]Version, error) {
+	versions := make([]Version, 0, len(vs))
+	var errs []error
+	for i, v := range vs {
+		ver, err := Parse(v)
+		if err != nil {
+			errs = append(errs, xerrors.Errorf("index %d: %w", i, err))
+			continue
+		}
+		versions = append(versions, ver)
+	}
+	return versions, errors.Join(errs...)
+}
+
+// New returns a new Version built from the given components without going through Parse.
+// The pre-release, post-release, development release and local segments can be set via options.
+func New(epoch uint64, release []uint64, opts ...VersionOption) (Version, error) {
+	if len(release) == 0 {
+		return Version{}, xerrors.Errorf("release segment must not be empty: %w", ErrInvalidVersion)
+	}
+
+	c := new(components)
+
+	// Apply options
+	for _, o := range opts {
+		o.apply(c)
+	}
+
+	if c.preLetter != "" || c.pre.number != 0 {
+		letter, ok := preReleaseAliases[strings.ToLower(c.preLetter)]
+		if !ok {
+			return Version{}, xerrors.Errorf("invalid pre-release letter (%s): %w", c.preLetter, ErrInvalidVersion)
+		}
+		c.pre.letter = letter
+	}
+
+	if c.local != "" {
+		if !localRegex().MatchString(c.local) {
+			return Version{}, xerrors.Errorf("invalid local version (%s): %w", c.local, ErrInvalidVersion)
+		}
+		c.local = strings.ToLower(c.local)
+	}
+
+	r := make([]part.Uint64, len(release))
+	for i, n := range release {
+		r[i] = part.Uint64(n)
+	}
+
+	ver := newVersion(part.Uint64(epoch), r, c.pre, c.post, c.dev, c.local)
+	ver.original = ver.String()
+
+	return ver, nil
+}
+
+func newVersion(epoch part.Uint64, release []part.Uint64, pre, post, dev letterNumber, local string) Version {
 	return Version{
-		epoch:    epoch,
-		release:  release,
-		pre:      pre,
-		post:     post,
-		dev:      dev,
-		local:    local,
-		key:      cmpkey(epoch, release, pre, post, dev, local),
-		original: v,
-	}, nil
+		epoch:   epoch,
+		release: release,
+		pre:     pre,
+		post:    post,
+		dev:     dev,
+		local:   local,
+	}
 }
 
 // UnmarshalText implements [encoding.TextUnmarshaler].
@@ -184,73 +408,196 @@ func (v *Version) UnmarshalText(data []byte) error {
 	return err
 }
 
+// compareVersions compares the versions following the ordering of packaging's _cmpkey, directly on the fields
+// rather than on a stored comparison key, so that versions stay small.
 // ref. https://github.com/pypa/packaging/blob/a6407e3a7e19bd979e93f58cfc7f6641a7378c46/packaging/version.py#L495
-func cmpkey(epoch part.Uint64, release []part.Uint64, pre, post, dev letterNumber, local string) key {
-	// Set default values
-	k := key{
-		epoch: epoch,
-		pre:   part.Parts{pre.letter, pre.number},
-		post:  part.Parts{post.letter, post.number},
-		dev:   part.Parts{dev.letter, dev.number},
-		local: part.NegativeInfinity,
+func compareVersions(a, b Version) int {
+	if c := cmp.Compare(a.infinity, b.infinity); c != 0 || a.infinity != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.epoch, b.epoch); c != 0 {
+		return c
+	}
+	if c := compareRelease(a.release, b.release); c != 0 {
+		return c
 	}
-
-	// Remove trailing zeros
-	k.release = part.Uint64SliceToParts(release).Normalize()
 
 	// https://github.com/pypa/packaging/blob/a6407e3a7e19bd979e93f58cfc7f6641a7378c46/packaging/version.py#L514-L517
-	if pre.isNull() && post.isNull() && !dev.isNull() {
-		k.pre = part.NegativeInfinity
-	} else if pre.isNull() {
-		k.pre = part.Infinity
+	if c := cmp.Compare(a.preRank(), b.preRank()); c != 0 {
+		return c
+	} else if !a.pre.isNull() {
+		if c := a.pre.compare(b.pre); c != 0 {
+			return c
+		}
 	}
 
 	// Versions without a post segment should sort before those with one.
-	if post.isNull() {
-		k.post = part.NegativeInfinity
+	if c := compareSegment(a.post, b.post, -1); c != 0 {
+		return c
 	}
 
 	// Versions without a development segment should sort after those with one.
-	if dev.isNull() {
-		k.dev = part.Infinity
-	}
-
-	// Versions with a local segment need that segment parsed to implement the sorting rules in PEP440.
-	//   - Alpha numeric segments sort before numeric segments
-	//   - Alpha numeric segments sort lexicographically
-	//   - Numeric segments sort numerically
-	//   - Shorter versions sort before longer versions when the prefixes match exactly
-	if local != "" {
-		var parts part.Parts
-		for _, l := range strings.Split(local, ".") {
-			if p, err := part.NewUint64(l); err == nil {
-				parts = append(parts, p)
-			} else {
-				parts = append(parts, part.NewPreString(l))
-			}
+	if c := compareSegment(a.dev, b.dev, 1); c != 0 {
+		return c
+	}
+
+	return compareLocal(a.local, b.local)
+}
+
+// preRank returns -1 if the version sorts before any pre-release of its release (a development release without
+// pre and post segments), 1 if it sorts after them (no pre-release) and 0 if it is a pre-release.
+func (v Version) preRank() int {
+	switch {
+	case v.pre.isNull() && v.post.isNull() && !v.dev.isNull():
+		return -1
+	case v.pre.isNull():
+		return 1
+	}
+	return 0
+}
+
+// compareSegment compares the segments. A missing segment compares as -1 (before) or 1 (after) any present one.
+func compareSegment(a, b letterNumber, missing int) int {
+	switch {
+	case a.isNull() && b.isNull():
+		return 0
+	case a.isNull():
+		return missing
+	case b.isNull():
+		return -missing
+	}
+	return a.compare(b)
+}
+
+// compareRelease compares the release segments as if the shorter one were padded with zeros.
+func compareRelease(a, b []part.Uint64) int {
+	for i := 0; i < max(len(a), len(b)); i++ {
+		var x, y part.Uint64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := cmp.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareLocal compares normalized local version segments. An empty one sorts before any other.
+// Versions with a local segment need that segment parsed to implement the sorting rules in PEP440.
+//   - Alpha numeric segments sort before numeric segments
+//   - Alpha numeric segments sort lexicographically
+//   - Numeric segments sort numerically
+//   - Shorter versions sort before longer versions when the prefixes match exactly
+func compareLocal(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+
+	for a != "" && b != "" {
+		var x, y string
+		x, a, _ = strings.Cut(a, ".")
+		y, b, _ = strings.Cut(b, ".")
+
+		xn, xerr := strconv.ParseUint(x, 10, 64)
+		yn, yerr := strconv.ParseUint(y, 10, 64)
+		var c int
+		switch {
+		case xerr == nil && yerr == nil:
+			c = cmp.Compare(xn, yn)
+		case xerr == nil:
+			c = 1
+		case yerr == nil:
+			c = -1
+		default:
+			c = strings.Compare(x, y)
+		}
+		if c != 0 {
+			return c
 		}
-		k.local = parts
 	}
+	return cmp.Compare(len(a), len(b))
+}
 
-	return k
+// CompareLocal compares two local version labels (the part after "+") following the PEP 440 ordering rules.
+// An empty label sorts before any other label.
+// It returns -1, 0, or 1 if a is smaller, equal, or larger than b, respectively.
+func CompareLocal(a, b string) (int, error) {
+	for _, l := range []string{a, b} {
+		if l != "" && !localRegex().MatchString(l) {
+			return 0, xerrors.Errorf("invalid local version (%s): %w", l, ErrInvalidVersion)
+		}
+	}
+	return compareLocal(strings.ToLower(a), strings.ToLower(b)), nil
 }
 
 // Compare compares this version to another version. This
 // returns -1, 0, or 1 if this version is smaller, equal,
 // or larger than the other version, respectively.
 func (v Version) Compare(other Version) int {
-	// A quick, efficient equality check
-	if v.String() == other.String() {
-		return 0
+	return compareVersions(v, other)
+}
+
+// Compare returns -1, 0, or 1 if a is smaller, equal, or larger than b, respectively.
+// It can be passed directly to slices.SortFunc, slices.BinarySearchFunc and so on.
+func Compare(a, b Version) int {
+	return a.Compare(b)
+}
+
+// CompareWith compares a and b like Compare, with the ordering adjusted by the given options.
+func CompareWith(a, b Version, opts ...CompareOption) int {
+	c := new(compareConf)
+
+	// Apply options
+	for _, o := range opts {
+		o.apply(c)
 	}
 
-	k1 := v.key
-	k2 := other.key
+	if c.ignoreEpoch {
+		a.epoch = 0
+		b.epoch = 0
+	}
+	if c.ignoreLocal {
+		a.local = ""
+		b.local = ""
+	}
 
-	k1.release = k1.release.Padding(len(k2.release), part.Zero)
-	k2.release = k2.release.Padding(len(k2.release), part.Zero)
+	if r := a.Compare(b); r != 0 || !c.tiebreak {
+		return r
+	}
+	return strings.Compare(a.original, b.original)
+}
 
-	return k1.compare(k2)
+// CompareRelease compares only the epoch and release segments of a and b, ignoring
+// pre-release, post-release, development release and local segments (e.g. 1.0rc1 and 1.0.post1 are equal).
+// It returns -1, 0, or 1 if a is smaller, equal, or larger than b, respectively.
+func CompareRelease(a, b Version) int {
+	if c := cmp.Compare(a.epoch, b.epoch); c != 0 {
+		return c
+	}
+	return compareRelease(a.release, b.release)
+}
+
+// CompareStrings parses the given versions and compares them.
+// See Compare for the meaning of the result.
+func CompareStrings(a, b string) (int, error) {
+	v1, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	v2, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return v1.Compare(v2), nil
 }
 
 // Equal tests if two versions are equal.
@@ -278,49 +625,123 @@ func (v Version) LessThanOrEqual(o Version) bool {
 	return v.Compare(o) <= 0
 }
 
+// Between tests if this version lies between low and high.
+// Both bounds are included if inclusive is true, otherwise both are excluded.
+func (v Version) Between(low, high Version, inclusive bool) bool {
+	if inclusive {
+		return v.GreaterThanOrEqual(low) && v.LessThanOrEqual(high)
+	}
+	return v.GreaterThan(low) && v.LessThan(high)
+}
+
+// Clamp returns low if v is less than low, high if v is greater than high, and v otherwise.
+func Clamp(v, low, high Version) Version {
+	if v.LessThan(low) {
+		return low
+	} else if v.GreaterThan(high) {
+		return high
+	}
+	return v
+}
+
 // String returns the full version string included pre-release
 // and metadata information.
 func (v Version) String() string {
-	var buf bytes.Buffer
+	switch v.infinity {
+	case -1:
+		return "-inf"
+	case 1:
+		return "inf"
+	}
+
+	buf := v.appendPublic(make([]byte, 0, 32))
 
+	// Local version segment
+	if v.local != "" {
+		buf = append(buf, '+')
+		buf = append(buf, v.local...)
+	}
+
+	return string(buf)
+}
+
+// appendPublic appends the version string without the local version segment to dst.
+func (v Version) appendPublic(dst []byte) []byte {
 	// Epoch
 	if v.epoch != 0 {
-		fmt.Fprintf(&buf, "%d!", v.epoch)
+		dst = strconv.AppendUint(dst, uint64(v.epoch), 10)
+		dst = append(dst, '!')
 	}
 
 	// Release segment
-	if len(v.release) != 0 {
-		fmt.Fprintf(&buf, "%d", v.release[0])
-		for _, r := range v.release[1:] {
-			fmt.Fprintf(&buf, ".%d", r)
+	for i, r := range v.release {
+		if i > 0 {
+			dst = append(dst, '.')
 		}
+		dst = strconv.AppendUint(dst, uint64(r), 10)
 	}
 
 	// Pre-release
 	if !v.pre.isNull() {
-		fmt.Fprintf(&buf, "%s%d", v.pre.letter, v.pre.number)
+		dst = append(dst, v.pre.letter.String()...)
+		dst = strconv.AppendUint(dst, uint64(v.pre.number), 10)
 	}
 
 	// Post-release
 	if !v.post.isNull() {
-		fmt.Fprintf(&buf, ".post%d", v.post.number)
+		dst = append(dst, ".post"...)
+		dst = strconv.AppendUint(dst, uint64(v.post.number), 10)
 	}
 
 	// Development release
 	if !v.dev.isNull() {
-		fmt.Fprintf(&buf, ".dev%d", v.dev.number)
+		dst = append(dst, ".dev"...)
+		dst = strconv.AppendUint(dst, uint64(v.dev.number), 10)
 	}
 
-	// Local version segment
+	return dst
+}
+
+// Key returns a canonical string that is identical for versions that are equal under PEP 440
+// (e.g. 1.0, 1.0.0 and v1.0 share the same key), so that it can be used as a map key.
+// The key is the normalized form with trailing zeros removed from the release segment and
+// leading zeros removed from numeric local segments. This format is stable across releases of this library.
+func (v Version) Key() string {
+	ver := v
+
+	release := v.release
+	for len(release) > 1 && release[len(release)-1] == 0 {
+		release = release[:len(release)-1]
+	}
+	ver.release = release
+
 	if v.local != "" {
-		fmt.Fprintf(&buf, "+%s", v.local)
+		var local []string
+		for _, l := range strings.Split(v.local, ".") {
+			if n, err := part.NewUint64(l); err == nil {
+				l = fmt.Sprint(n)
+			}
+			local = append(local, l)
+		}
+		ver.local = strings.Join(local, ".")
 	}
 
-	return buf.String()
+	return ver.String()
+}
+
+// Hash returns the 64-bit FNV-1a hash of Key, so versions that are equal under PEP 440 have the same hash.
+// The hash is stable across processes and releases of this library.
+func (v Version) Hash() uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(v.Key()))
+	return h.Sum64()
 }
 
 // MarshalText implements [encoding.TextMarshaler].
 func (v Version) MarshalText() ([]byte, error) {
+	if v.infinity != 0 {
+		return nil, xerrors.Errorf("marshaling %s: %w", v, ErrInvalidVersion)
+	}
 	return []byte(v.String()), nil
 }
 
@@ -344,20 +765,288 @@ func (v Version) BaseVersion() string {
 	return buf.String()
 }
 
+// NextMajor returns a new version with the major component incremented.
+// The remaining release components are reset to 0 and pre, post, dev and local segments are cleared.
+func (v Version) NextMajor() Version {
+	return v.bump(0)
+}
+
+// NextMinor returns a new version with the minor component incremented.
+// The remaining release components are reset to 0 and pre, post, dev and local segments are cleared.
+func (v Version) NextMinor() Version {
+	return v.bump(1)
+}
+
+// NextMicro returns a new version with the micro component incremented.
+// The remaining release components are reset to 0 and pre, post, dev and local segments are cleared.
+func (v Version) NextMicro() Version {
+	return v.bump(2)
+}
+
+// NextPost returns the next post-release of the version (e.g. 1.0 -> 1.0.post1, 1.0.post1 -> 1.0.post2).
+// The development release and local segments are cleared.
+func (v Version) NextPost() Version {
+	if len(v.release) == 0 {
+		return v
+	}
+	return v.derive(func(ver *Version) {
+		ver.post = letterNumber{letter: postQualifier, number: v.post.number + 1}
+		ver.dev = letterNumber{}
+		ver.local = ""
+	})
+}
+
+// NextDev returns the next development release of the version (e.g. 1.0.dev3 -> 1.0.dev4).
+// If the version has no development release segment, .dev1 is appended, which sorts before the version itself.
+// The local segment is cleared.
+func (v Version) NextDev() Version {
+	if len(v.release) == 0 {
+		return v
+	}
+	return v.derive(func(ver *Version) {
+		ver.dev = letterNumber{letter: devQualifier, number: v.dev.number + 1}
+		ver.local = ""
+	})
+}
+
+// WithLocal returns a copy of the version with the local segment replaced.
+func (v Version) WithLocal(local string) (Version, error) {
+	if !localRegex().MatchString(local) {
+		return Version{}, xerrors.Errorf("invalid local version (%s): %w", local, ErrInvalidVersion)
+	}
+	return v.derive(func(ver *Version) {
+		ver.local = strings.ToLower(local)
+	}), nil
+}
+
+// WithoutLocal returns a copy of the version with the local segment removed.
+func (v Version) WithoutLocal() Version {
+	return v.derive(func(ver *Version) {
+		ver.local = ""
+	})
+}
+
+// withoutLocal is a cheaper WithoutLocal for comparisons, which keeps the original string as-is.
+func (v Version) withoutLocal() Version {
+	v.local = ""
+	return v
+}
+
+// WithEpoch returns a copy of the version with the given epoch.
+func (v Version) WithEpoch(epoch uint64) Version {
+	return v.derive(func(ver *Version) {
+		ver.epoch = part.Uint64(epoch)
+	})
+}
+
+// Truncate returns a copy of the version keeping only the first n release components
+// (e.g. 1.2.3.4 -> 1.2 for n = 2). The pre, post, dev and local segments are dropped.
+// At least one release component is always kept. A version without a release segment, like the zero Version,
+// is returned as is.
+func (v Version) Truncate(n int) Version {
+	if len(v.release) == 0 {
+		return v
+	}
+	n = max(min(n, len(v.release)), 1)
+	return v.derive(func(ver *Version) {
+		ver.release = v.release[:n:n]
+		ver.pre = letterNumber{}
+		ver.post = letterNumber{}
+		ver.dev = letterNumber{}
+		ver.local = ""
+	})
+}
+
+// Successor returns the smallest public version strictly greater than this version
+// (e.g. 1.0 -> 1.0.post0.dev0, 1.0.post1 -> 1.0.post2.dev0, 1.0.dev1 -> 1.0.dev2).
+// It can be used to turn an exclusive lower bound into an inclusive one.
+// Local versions are not taken into account.
+func (v Version) Successor() Version {
+	if len(v.release) == 0 {
+		return v
+	}
+	return v.derive(func(ver *Version) {
+		ver.local = ""
+		switch {
+		case !v.dev.isNull():
+			ver.dev.number++
+		case !v.post.isNull():
+			ver.post.number++
+			ver.dev = letterNumber{letter: devQualifier}
+		default:
+			ver.post = letterNumber{letter: postQualifier}
+			ver.dev = letterNumber{letter: devQualifier}
+		}
+	})
+}
+
+// Predecessor returns the largest public version strictly smaller than this version.
+// It is the inverse of Successor and can be used to turn an exclusive upper bound into an inclusive one.
+// ok is false if no such version can be represented (e.g. 1.0 is preceded by infinitely many 1.0rcN releases).
+func (v Version) Predecessor() (Version, bool) {
+	switch {
+	case v.local != "":
+		return v.WithoutLocal(), true
+	case v.dev.isNull():
+		return Version{}, false
+	case v.dev.number > 0:
+		return v.derive(func(ver *Version) {
+			ver.dev.number--
+		}), true
+	case v.post.isNull():
+		return Version{}, false
+	}
+
+	return v.derive(func(ver *Version) {
+		ver.dev = letterNumber{}
+		if v.post.number == 0 {
+			ver.post = letterNumber{}
+		} else {
+			ver.post.number--
+		}
+	}), true
+}
+
+// derive returns a modified copy of the version with the original string rebuilt.
+func (v Version) derive(f func(*Version)) Version {
+	ver := v
+	f(&ver)
+	ver.original = ver.String()
+	return ver
+}
+
+func (v Version) bump(i int) Version {
+	if len(v.release) == 0 {
+		return v
+	}
+	release := make([]part.Uint64, max(len(v.release), i+1))
+	copy(release, v.release[:min(len(v.release), i)])
+	release[i] = part.Uint64(v.releaseAt(i) + 1)
+
+	return v.derive(func(ver *Version) {
+		ver.release = release
+		ver.pre = letterNumber{}
+		ver.post = letterNumber{}
+		ver.dev = letterNumber{}
+		ver.local = ""
+	})
+}
+
 // Original returns the original parsed version as-is, including any
 // potential whitespace, `v` prefix, etc.
 func (v Version) Original() string {
 	return v.original
 }
 
+// Epoch returns the epoch of the version
+func (v Version) Epoch() uint64 {
+	return uint64(v.epoch)
+}
+
+// Release returns a copy of the release segment
+func (v Version) Release() []uint64 {
+	release := make([]uint64, len(v.release))
+	for i, r := range v.release {
+		release[i] = uint64(r)
+	}
+	return release
+}
+
+// Major returns the first component of the release segment, or 0 if it is missing.
+func (v Version) Major() uint64 {
+	return v.releaseAt(0)
+}
+
+// Minor returns the second component of the release segment, or 0 if it is missing.
+func (v Version) Minor() uint64 {
+	return v.releaseAt(1)
+}
+
+// Micro returns the third component of the release segment, or 0 if it is missing.
+func (v Version) Micro() uint64 {
+	return v.releaseAt(2)
+}
+
+func (v Version) releaseAt(i int) uint64 {
+	if i >= len(v.release) {
+		return 0
+	}
+	return uint64(v.release[i])
+}
+
+// Pre returns the normalized pre-release letter ("a", "b" or "rc") and number.
+// ok is false if the version is not a pre-release.
+func (v Version) Pre() (letter string, number uint64, ok bool) {
+	if v.pre.isNull() {
+		return "", 0, false
+	}
+	return v.pre.letter.String(), uint64(v.pre.number), true
+}
+
+// PreReleasePhase returns the pre-release phase and number.
+// NoPreRelease is returned if the version is not a pre-release.
+func (v Version) PreReleasePhase() (PreReleasePhase, uint64) {
+	if v.pre.isNull() {
+		return NoPreRelease, 0
+	}
+	return preReleasePhases[v.pre.letter], uint64(v.pre.number)
+}
+
+// Post returns the post-release number.
+// ok is false if the version has no post-release segment.
+func (v Version) Post() (number uint64, ok bool) {
+	if v.post.isNull() {
+		return 0, false
+	}
+	return uint64(v.post.number), true
+}
+
+// Dev returns the development release number.
+// ok is false if the version has no development release segment.
+func (v Version) Dev() (number uint64, ok bool) {
+	if v.dev.isNull() {
+		return 0, false
+	}
+	return uint64(v.dev.number), true
+}
+
 // Local returns the local version
 func (v Version) Local() string {
 	return v.local
 }
 
+// LocalSegment represents a single dot-separated component of a local version label.
+type LocalSegment struct {
+	// Value is the normalized (lower-cased) text of the component.
+	Value string
+	// Number is the numeric value of the component. It is only meaningful when IsNumeric is true.
+	Number uint64
+	// IsNumeric reports whether the component consists only of digits.
+	// Numeric components sort numerically and after alphanumeric ones.
+	IsNumeric bool
+}
+
+// LocalSegments returns the components of the local version label, or nil if there is none.
+func (v Version) LocalSegments() []LocalSegment {
+	if v.local == "" {
+		return nil
+	}
+
+	var segments []LocalSegment
+	for _, l := range strings.Split(v.local, ".") {
+		s := LocalSegment{Value: l}
+		if n, err := part.NewUint64(l); err == nil {
+			s.Number = uint64(n)
+			s.IsNumeric = true
+		}
+		segments = append(segments, s)
+	}
+	return segments
+}
+
 // Public returns the public version
 func (v Version) Public() string {
-	return strings.SplitN(v.String(), "+", 2)[0]
+	return string(v.appendPublic(nil))
 }
 
 // IsPreRelease returns if it is a pre-release
@@ -372,3 +1061,20 @@ func (v Version) IsPreRelease() bool {
 func (v Version) IsPostRelease() bool {
 	return !v.post.isNull()
 }
+
+// IsDevRelease returns if it is a development release
+func (v Version) IsDevRelease() bool {
+	return !v.dev.isNull()
+}
+
+// IsLocal returns if it has a local version segment
+func (v Version) IsLocal() bool {
+	return v.local != ""
+}
+
+// IsFinal returns if it is a final release, that is, it consists solely of a release segment and an optional epoch
+func (v Version) IsFinal() bool {
+	return len(v.release) != 0 && v.pre.isNull() && v.post.isNull() && v.dev.isNull() && v.local == ""
+}
+
+func (Version) anyVersion() {}
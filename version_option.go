@@ -0,0 +1,74 @@
+package version
+
+import (
+	"github.com/aquasecurity/go-version/pkg/part"
+)
+
+type components struct {
+	// preLetter is the pre-release letter as given, which New validates and normalizes into pre.
+	preLetter string
+	pre       letterNumber
+	post      letterNumber
+	dev       letterNumber
+	local     string
+}
+
+type VersionOption interface {
+	apply(*components)
+}
+
+// WithPre sets the pre-release segment. Letter accepts any spelling allowed by PEP 440 (e.g. "alpha", "c").
+type WithPre struct {
+	Letter string
+	Number uint64
+}
+
+func (o WithPre) apply(c *components) {
+	c.preLetter = o.Letter
+	c.pre = letterNumber{number: part.Uint64(o.Number)}
+}
+
+// WithPost sets the post-release number.
+type WithPost uint64
+
+func (o WithPost) apply(c *components) {
+	c.post = letterNumber{letter: postQualifier, number: part.Uint64(o)}
+}
+
+// WithDev sets the development release number.
+type WithDev uint64
+
+func (o WithDev) apply(c *components) {
+	c.dev = letterNumber{letter: devQualifier, number: part.Uint64(o)}
+}
+
+// WithLocal sets the local version label.
+type WithLocal string
+
+func (o WithLocal) apply(c *components) {
+	c.local = string(o)
+}
+
+type parseConf struct {
+	strict    bool
+	maxLength int
+}
+
+type ParseOption interface {
+	apply(*parseConf)
+}
+
+// WithStrict rejects versions which are valid but not in their canonical form (e.g. 1.0DEV, v1.0, 1.01).
+type WithStrict bool
+
+func (o WithStrict) apply(c *parseConf) {
+	c.strict = bool(o)
+}
+
+// WithMaxVersionLength rejects versions longer than the given number of bytes with ErrInputTooLong,
+// before any parsing is done. It is meant for parsing untrusted input. Zero or a negative number means no limit.
+type WithMaxVersionLength int
+
+func (o WithMaxVersionLength) apply(c *parseConf) {
+	c.maxLength = int(o)
+}
@@ -2,7 +2,10 @@ package version_test
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -357,3 +360,801 @@ func TestVersion_JSON(t *testing.T) {
 		}
 	})
 }
+
+func TestVersion_Accessors(t *testing.T) {
+	tests := []struct {
+		version string
+		epoch   uint64
+		release []uint64
+		pre     string
+		preN    uint64
+		preOK   bool
+		post    uint64
+		postOK  bool
+		dev     uint64
+		devOK   bool
+		local   string
+	}{
+		{
+			version: "1.2.3",
+			release: []uint64{1, 2, 3},
+		},
+		{
+			version: "2!1.0a5.post3.dev2+ubuntu.1",
+			epoch:   2,
+			release: []uint64{1, 0},
+			pre:     "a",
+			preN:    5,
+			preOK:   true,
+			post:    3,
+			postOK:  true,
+			dev:     2,
+			devOK:   true,
+			local:   "ubuntu.1",
+		},
+		{
+			version: "1.0c1",
+			release: []uint64{1, 0},
+			pre:     "rc",
+			preN:    1,
+			preOK:   true,
+		},
+		{
+			version: "1.0-5",
+			release: []uint64{1, 0},
+			post:    5,
+			postOK:  true,
+		},
+		{
+			version: "1.0.dev",
+			release: []uint64{1, 0},
+			devOK:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := version.MustParse(tt.version)
+			assert.Equal(t, tt.epoch, v.Epoch())
+			assert.Equal(t, tt.release, v.Release())
+
+			pre, preN, ok := v.Pre()
+			assert.Equal(t, tt.pre, pre)
+			assert.Equal(t, tt.preN, preN)
+			assert.Equal(t, tt.preOK, ok)
+
+			post, ok := v.Post()
+			assert.Equal(t, tt.post, post)
+			assert.Equal(t, tt.postOK, ok)
+
+			dev, ok := v.Dev()
+			assert.Equal(t, tt.dev, dev)
+			assert.Equal(t, tt.devOK, ok)
+
+			assert.Equal(t, tt.local, v.Local())
+		})
+	}
+}
+
+func TestVersion_MajorMinorMicro(t *testing.T) {
+	tests := []struct {
+		version string
+		want    [3]uint64
+	}{
+		{"1", [3]uint64{1, 0, 0}},
+		{"1.2", [3]uint64{1, 2, 0}},
+		{"1.2.3", [3]uint64{1, 2, 3}},
+		{"1.2.3.4", [3]uint64{1, 2, 3}},
+		{"1!4.5rc1", [3]uint64{4, 5, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := version.MustParse(tt.version)
+			assert.Equal(t, tt.want, [3]uint64{v.Major(), v.Minor(), v.Micro()})
+		})
+	}
+	t.Run("Zero Value", func(t *testing.T) {
+		v := version.Version{}
+		assert.Equal(t, [3]uint64{}, [3]uint64{v.Major(), v.Minor(), v.Micro()})
+	})
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		epoch   uint64
+		release []uint64
+		opts    []version.VersionOption
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "release only",
+			release: []uint64{1, 2, 3},
+			want:    "1.2.3",
+		},
+		{
+			name:    "all segments",
+			epoch:   1,
+			release: []uint64{2, 0},
+			opts: []version.VersionOption{
+				version.WithPre{Letter: "alpha", Number: 1},
+				version.WithPost(2),
+				version.WithDev(3),
+				version.WithLocal("Ubuntu.1"),
+			},
+			want: "1!2.0a1.post2.dev3+ubuntu.1",
+		},
+		{
+			name:    "zero dev",
+			release: []uint64{1, 0},
+			opts:    []version.VersionOption{version.WithDev(0)},
+			want:    "1.0.dev0",
+		},
+		{
+			name:    "empty release",
+			wantErr: "release segment must not be empty",
+		},
+		{
+			name:    "invalid pre-release letter",
+			release: []uint64{1},
+			opts:    []version.VersionOption{version.WithPre{Letter: "gamma", Number: 1}},
+			wantErr: "invalid pre-release letter",
+		},
+		{
+			name:    "invalid local version",
+			release: []uint64{1},
+			opts:    []version.VersionOption{version.WithLocal("foo+bar")},
+			wantErr: "invalid local version",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := version.New(tt.epoch, tt.release, tt.opts...)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.True(t, got.Equal(version.MustParse(tt.want)))
+		})
+	}
+}
+
+func TestVersion_Next(t *testing.T) {
+	tests := []struct {
+		version   string
+		wantMajor string
+		wantMinor string
+		wantMicro string
+	}{
+		{"1", "2", "1.1", "1.0.1"},
+		{"1.2", "2.0", "1.3", "1.2.1"},
+		{"1.2.3", "2.0.0", "1.3.0", "1.2.4"},
+		{"1.2.3.4", "2.0.0.0", "1.3.0.0", "1.2.4.0"},
+		{"1!1.2.3rc1.post2.dev3+local", "1!2.0.0", "1!1.3.0", "1!1.2.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := version.MustParse(tt.version)
+			assert.Equal(t, tt.wantMajor, v.NextMajor().String())
+			assert.Equal(t, tt.wantMinor, v.NextMinor().String())
+			assert.Equal(t, tt.wantMicro, v.NextMicro().String())
+			assert.True(t, v.NextMicro().GreaterThan(v))
+		})
+	}
+}
+
+func TestVersion_NextPost_NextDev(t *testing.T) {
+	tests := []struct {
+		version  string
+		wantPost string
+		wantDev  string
+	}{
+		{"1.0", "1.0.post1", "1.0.dev1"},
+		{"1.0.post1", "1.0.post2", "1.0.post1.dev1"},
+		{"1.0.dev3", "1.0.post1", "1.0.dev4"},
+		{"1.0a1.post2.dev3+local", "1.0a1.post3", "1.0a1.post2.dev4"},
+		{"1!2.0-5", "1!2.0.post6", "1!2.0.post5.dev1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := version.MustParse(tt.version)
+			assert.Equal(t, tt.wantPost, v.NextPost().String())
+			assert.Equal(t, tt.wantDev, v.NextDev().String())
+		})
+	}
+}
+
+func TestVersion_Derive(t *testing.T) {
+	v := version.MustParse("1.0a1+abc.5")
+
+	t.Run("WithLocal", func(t *testing.T) {
+		got, err := v.WithLocal("Ubuntu-1")
+		require.NoError(t, err)
+		assert.Equal(t, "1.0a1+ubuntu-1", got.String())
+		assert.Equal(t, "1.0a1+abc.5", v.String())
+
+		_, err = v.WithLocal("")
+		assert.Error(t, err)
+		_, err = v.WithLocal("a+b")
+		assert.Error(t, err)
+	})
+
+	t.Run("WithoutLocal", func(t *testing.T) {
+		got := v.WithoutLocal()
+		assert.Equal(t, "1.0a1", got.String())
+		assert.True(t, got.LessThan(v))
+	})
+
+	t.Run("WithEpoch", func(t *testing.T) {
+		got := v.WithEpoch(2)
+		assert.Equal(t, "2!1.0a1+abc.5", got.String())
+		assert.True(t, got.GreaterThan(version.MustParse("1!9.0")))
+		assert.Equal(t, "1.0a1+abc.5", got.WithEpoch(0).String())
+	})
+}
+
+func TestVersion_Truncate(t *testing.T) {
+	tests := []struct {
+		version string
+		n       int
+		want    string
+	}{
+		{"1.2.3.4", 2, "1.2"},
+		{"1.2.3.4", 4, "1.2.3.4"},
+		{"1.2.3.4", 10, "1.2.3.4"},
+		{"1.2.3.4", 0, "1"},
+		{"1!1.2.3rc1.post1.dev1+local", 2, "1!1.2"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.version, tt.n), func(t *testing.T) {
+			v := version.MustParse(tt.version)
+			assert.Equal(t, tt.want, v.Truncate(tt.n).String())
+		})
+	}
+
+	assert.Equal(t, version.Version{}, version.Version{}.Truncate(2))
+}
+
+func TestVersion_Predicates(t *testing.T) {
+	tests := []struct {
+		version string
+		pre     bool
+		post    bool
+		dev     bool
+		local   bool
+		final   bool
+	}{
+		{version: "1.0", final: true},
+		{version: "1!1.0", final: true},
+		{version: "1.0a1", pre: true},
+		{version: "1.0.post1", post: true},
+		{version: "1.0.dev1", pre: true, dev: true},
+		{version: "1.0+local", local: true},
+		{version: "1.0rc1.post1.dev1+local", pre: true, post: true, dev: true, local: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := version.MustParse(tt.version)
+			assert.Equal(t, tt.pre, v.IsPreRelease())
+			assert.Equal(t, tt.post, v.IsPostRelease())
+			assert.Equal(t, tt.dev, v.IsDevRelease())
+			assert.Equal(t, tt.local, v.IsLocal())
+			assert.Equal(t, tt.final, v.IsFinal())
+		})
+	}
+}
+
+func TestVersion_PreReleasePhase(t *testing.T) {
+	tests := []struct {
+		version    string
+		wantPhase  version.PreReleasePhase
+		wantNumber uint64
+	}{
+		{"1.0", version.NoPreRelease, 0},
+		{"1.0.dev1", version.NoPreRelease, 0},
+		{"1.0alpha2", version.Alpha, 2},
+		{"1.0b", version.Beta, 0},
+		{"1.0c3", version.ReleaseCandidate, 3},
+		{"1.0preview4", version.ReleaseCandidate, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			phase, n := version.MustParse(tt.version).PreReleasePhase()
+			assert.Equal(t, tt.wantPhase, phase)
+			assert.Equal(t, tt.wantNumber, n)
+		})
+	}
+	assert.Equal(t, "rc", version.ReleaseCandidate.String())
+	assert.Equal(t, "", version.NoPreRelease.String())
+}
+
+func TestCompare(t *testing.T) {
+	var vs []version.Version
+	for i := len(versions) - 1; i >= 0; i-- {
+		vs = append(vs, version.MustParse(versions[i]))
+	}
+	slices.SortFunc(vs, version.Compare)
+
+	var got []string
+	for _, v := range vs {
+		got = append(got, v.Original())
+	}
+	assert.Equal(t, versions, got)
+
+	i, found := slices.BinarySearchFunc(vs, version.MustParse("1.0c1"), version.Compare)
+	assert.True(t, found)
+	assert.Equal(t, "1.0c1", vs[i].Original())
+}
+
+func TestInf(t *testing.T) {
+	assert.Equal(t, 0, version.NegInf.Compare(version.NegInf))
+	assert.Equal(t, 0, version.Inf.Compare(version.Inf))
+	assert.True(t, version.NegInf.LessThan(version.Inf))
+	assert.True(t, version.Inf.GreaterThan(version.NegInf))
+	assert.False(t, version.Inf.Equal(version.Version{}))
+	for _, s := range append(versions, "0.dev0", "0") {
+		v := version.MustParse(s)
+		assert.Equal(t, 1, v.Compare(version.NegInf), s)
+		assert.Equal(t, -1, version.NegInf.Compare(v), s)
+		assert.Equal(t, -1, v.Compare(version.Inf), s)
+		assert.Equal(t, 1, version.Inf.Compare(v), s)
+		assert.True(t, v.Between(version.NegInf, version.Inf, false), s)
+	}
+
+	assert.Equal(t, "-inf", version.NegInf.String())
+	assert.Equal(t, "inf", version.Inf.String())
+	assert.NotEqual(t, version.NegInf.Key(), version.Inf.Key())
+
+	ss, err := version.NewSpecifiers(">=0.dev0")
+	require.NoError(t, err)
+	assert.False(t, ss.Check(version.Inf))
+	assert.False(t, ss.Check(version.NegInf))
+
+	for _, v := range []version.Version{version.NegInf, version.Inf, {}} {
+		assert.Equal(t, v, v.NextMajor(), v)
+		assert.Equal(t, v, v.NextMinor(), v)
+		assert.Equal(t, v, v.NextMicro(), v)
+		assert.Equal(t, v, v.NextPost(), v)
+		assert.Equal(t, v, v.NextDev(), v)
+		assert.Equal(t, v, v.Successor(), v)
+		assert.Equal(t, v, v.Truncate(1), v)
+	}
+
+	_, err = version.Inf.MarshalText()
+	require.ErrorIs(t, err, version.ErrInvalidVersion)
+	_, err = version.NegInf.MarshalBinary()
+	require.ErrorIs(t, err, version.ErrInvalidVersion)
+	_, err = version.Inf.Value()
+	require.ErrorIs(t, err, version.ErrInvalidVersion)
+}
+
+func TestCompareStrings(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    int
+		wantErr bool
+	}{
+		{a: "1.0", b: "1.0.0", want: 0},
+		{a: "1.0a1", b: "1.0", want: -1},
+		{a: "1.0.post1", b: "1.0", want: 1},
+		{a: "french toast", b: "1.0", wantErr: true},
+		{a: "1.0", b: "1.0++", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			got, err := version.CompareStrings(tt.a, tt.b)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompareRelease(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0rc1", "1.0.post1", 0},
+		{"1.0.dev1", "1.0.0+local", 0},
+		{"1.0", "1.0.1a1", -1},
+		{"1.10", "1.9.post1", 1},
+		{"1!1.0", "2.0", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			v1, v2 := parseVersions(t, tt.a, tt.b)
+			assert.Equal(t, tt.want, version.CompareRelease(v1, v2))
+			assert.Equal(t, -tt.want, version.CompareRelease(v2, v1))
+		})
+	}
+}
+
+func TestCompareWith(t *testing.T) {
+	tests := []struct {
+		a, b string
+		opts []version.CompareOption
+		want int
+	}{
+		{"1!1.0", "2.0", nil, 1},
+		{"1!1.0", "2.0", []version.CompareOption{version.WithoutEpoch(true)}, -1},
+		{"1!1.0", "1.0", []version.CompareOption{version.WithoutEpoch(true)}, 0},
+		{"3!1.0a1", "1!1.0", []version.CompareOption{version.WithoutEpoch(true)}, -1},
+		{"1!1.0", "1.0", []version.CompareOption{version.WithoutEpoch(false)}, 1},
+		{"1.0+ubuntu1", "1.0", []version.CompareOption{version.WithoutLocal(true)}, 0},
+		{"1.0+ubuntu1", "1.0.post1", []version.CompareOption{version.WithoutLocal(true)}, -1},
+		{"1.0+ubuntu1", "1.0", nil, 1},
+		{"1.0", "1.0.0", []version.CompareOption{version.WithOriginalTiebreak(true)}, -1},
+		{"v1.0", "1.0.0", []version.CompareOption{version.WithOriginalTiebreak(true)}, 1},
+		{"1.0", "1.0.0", []version.CompareOption{version.WithOriginalTiebreak(false)}, 0},
+		{"1.0.post1", "1.0.0", []version.CompareOption{version.WithOriginalTiebreak(true)}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			v1, v2 := parseVersions(t, tt.a, tt.b)
+			assert.Equal(t, tt.want, version.CompareWith(v1, v2, tt.opts...))
+		})
+	}
+}
+
+func TestCompareLocal(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    int
+		wantErr bool
+	}{
+		{a: "abc", b: "abc", want: 0},
+		{a: "ABC", b: "abc", want: 0},
+		{a: "abc", b: "1", want: -1},
+		{a: "abc", b: "abd", want: -1},
+		{a: "10", b: "9", want: 1},
+		{a: "1.abc", b: "1", want: 1},
+		{a: "", b: "abc", want: -1},
+		{a: "", b: "", want: 0},
+		{a: "a+b", b: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			got, err := version.CompareLocal(tt.a, tt.b)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVersion_LocalSegments(t *testing.T) {
+	v := version.MustParse("1.0+Ubuntu.007")
+	assert.Equal(t, []version.LocalSegment{
+		{Value: "ubuntu"},
+		{Value: "007", Number: 7, IsNumeric: true},
+	}, v.LocalSegments())
+	assert.Nil(t, version.MustParse("1.0").LocalSegments())
+}
+
+func TestVersion_Between(t *testing.T) {
+	tests := []struct {
+		version   string
+		low, high string
+		inclusive bool
+		want      bool
+	}{
+		{"1.5", "1.0", "2.0", false, true},
+		{"1.0", "1.0", "2.0", false, false},
+		{"1.0", "1.0", "2.0", true, true},
+		{"2.0.0", "1.0", "2.0", true, true},
+		{"2.0.post1", "1.0", "2.0", true, false},
+		{"1.0rc1", "1.0", "2.0", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s %s %t", tt.version, tt.low, tt.high, tt.inclusive), func(t *testing.T) {
+			v := version.MustParse(tt.version)
+			low, high := parseVersions(t, tt.low, tt.high)
+			assert.Equal(t, tt.want, v.Between(low, high, tt.inclusive))
+		})
+	}
+}
+
+func TestClamp(t *testing.T) {
+	low, high := parseVersions(t, "1.0", "2.0")
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"0.9", "1.0"},
+		{"1.5", "1.5"},
+		{"2.0.post1", "2.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got := version.Clamp(version.MustParse(tt.version), low, high)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestVersion_Key(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"1.0", "1"},
+		{"1.0.0", "1"},
+		{"v1", "1"},
+		{"0", "0"},
+		{"0.0", "0"},
+		{"1.0.1", "1.0.1"},
+		{"0!1.0RC1", "1rc1"},
+		{"1!1.0-5", "1!1.post5"},
+		{"1.0+ABC.007", "1+abc.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, version.MustParse(tt.version).Key())
+		})
+	}
+
+	t.Run("Map", func(t *testing.T) {
+		m := map[string]version.Version{}
+		for _, v := range []string{"1.0", "1.0.0", "v1", "1.0+local"} {
+			ver := version.MustParse(v)
+			m[ver.Key()] = ver
+		}
+		assert.Len(t, m, 2)
+	})
+}
+
+func TestVersion_Hash(t *testing.T) {
+	// The hash must not change across releases
+	assert.Equal(t, uint64(0xaf63ac4c86019afc), version.MustParse("1.0.0").Hash())
+	assert.Equal(t, version.MustParse("1.0").Hash(), version.MustParse("v1.0.0").Hash())
+	assert.NotEqual(t, version.MustParse("1.0").Hash(), version.MustParse("1.0.post0").Hash())
+}
+
+func TestVersion_Successor(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"1.0", "1.0.post0.dev0"},
+		{"1.0a1", "1.0a1.post0.dev0"},
+		{"1.0.post1", "1.0.post2.dev0"},
+		{"1.0.dev1", "1.0.dev2"},
+		{"1.0.post1.dev1", "1.0.post1.dev2"},
+		{"1!1.0+local", "1!1.0.post0.dev0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := version.MustParse(tt.version)
+			got := v.Successor()
+			assert.Equal(t, tt.want, got.String())
+			assert.True(t, got.GreaterThan(v))
+
+			if v.Local() == "" {
+				pred, ok := got.Predecessor()
+				require.True(t, ok)
+				assert.True(t, pred.Equal(v))
+			}
+		})
+	}
+}
+
+func TestVersion_Predecessor(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+		wantOK  bool
+	}{
+		{"1.0.dev2", "1.0.dev1", true},
+		{"1.0.post2.dev0", "1.0.post1", true},
+		{"1.0.post0.dev0", "1.0", true},
+		{"1.0+local", "1.0", true},
+		{"1.0", "", false},
+		{"1.0.post1", "", false},
+		{"1.0.dev0", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v := version.MustParse(tt.version)
+			got, ok := v.Predecessor()
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, got.String())
+				assert.True(t, got.LessThan(v))
+			}
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	t.Run("all valid", func(t *testing.T) {
+		got, err := version.ParseAll([]string{"1.0", "2.0rc1"})
+		require.NoError(t, err)
+		assert.Equal(t, []version.Version{version.MustParse("1.0"), version.MustParse("2.0rc1")}, got)
+	})
+
+	t.Run("some invalid", func(t *testing.T) {
+		got, err := version.ParseAll([]string{"1.0", "french toast", "2.0", "1.0++"})
+		require.Error(t, err)
+		assert.Equal(t, []version.Version{version.MustParse("1.0"), version.MustParse("2.0")}, got)
+		assert.ErrorIs(t, err, version.ErrInvalidVersion)
+		assert.Contains(t, err.Error(), "index 1: french toast")
+		assert.Contains(t, err.Error(), "index 3: 1.0++")
+	})
+}
+
+func TestIsValid(t *testing.T) {
+	for _, v := range versions {
+		assert.True(t, version.IsValid(v), v)
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"  v1.0\t\n", true},
+		{"1.18446744073709551615", true},
+		{"1.18446744073709551616", false},
+		{"1.0+99999999999999999999", true},
+		{"french toast", false},
+		{"1.0+a+", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			_, err := version.Parse(tt.version)
+			assert.Equal(t, err == nil, version.IsValid(tt.version))
+			assert.Equal(t, tt.want, version.IsValid(tt.version))
+		})
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		version.IsValid("1!1.0b2.post345.dev456+abc.1")
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+		wantErr bool
+	}{
+		{version: "1.4.0", want: "1.4"},
+		{version: "1.40.0", want: "1.40"},
+		{version: "1.4.0.0.00.000.0000", want: "1.4"},
+		{version: "1.0", want: "1"},
+		{version: "1.0+local", want: "1+local"},
+		{version: "1.0.0.post5", want: "1.post5"},
+		{version: "v1.0.0-RC1", want: "1rc1"},
+		{version: "1.0.dev456", want: "1.dev456"},
+		{version: "french toast", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := version.Canonicalize(tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseWith_Strict(t *testing.T) {
+	tests := []struct {
+		version    string
+		wantOffset int
+		wantErr    bool
+	}{
+		{version: "1.0"},
+		{version: "1!1.0rc1.post2.dev3+abc.1"},
+		{version: "1.0DEV", wantOffset: 3, wantErr: true},
+		{version: "v1.0", wantOffset: 0, wantErr: true},
+		{version: "1.01", wantOffset: 2, wantErr: true},
+		{version: " 1.0", wantOffset: 0, wantErr: true},
+		{version: "1.0-1", wantOffset: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := version.ParseWith(tt.version, version.WithStrict(true))
+			if !tt.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, tt.version, got.String())
+				return
+			}
+
+			var parseErr *version.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.ErrorIs(t, err, version.ErrNotCanonical)
+			assert.Equal(t, tt.wantOffset, parseErr.Offset)
+			assert.Equal(t, version.MustParse(tt.version).String(), parseErr.Suggestion)
+
+			_, err = version.ParseWith(tt.version, version.WithStrict(false))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestVersion_Text(t *testing.T) {
+	type vs struct {
+		XMLName xml.Name        `xml:"package"`
+		Version version.Version `xml:"version,attr"`
+		Latest  version.Version `xml:"latest"`
+	}
+	xmlString := `<package version="1.0.post456.dev34"><latest>2!1.0rc1</latest></package>`
+	want := vs{
+		XMLName: xml.Name{Local: "package"},
+		Version: version.MustParse("1.0.post456.dev34"),
+		Latest:  version.MustParse("2!1.0rc1"),
+	}
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		var got vs
+		require.NoError(t, xml.Unmarshal([]byte(xmlString), &got))
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		got, err := xml.Marshal(want)
+		require.NoError(t, err)
+		assert.Equal(t, xmlString, string(got))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var got vs
+		err := xml.Unmarshal([]byte(`<package version="french toast"></package>`), &got)
+		assert.ErrorIs(t, err, version.ErrInvalidVersion)
+	})
+}
+
+func TestParseWith_MaxVersionLength(t *testing.T) {
+	_, err := version.ParseWith("1.0.0", version.WithMaxVersionLength(5))
+	assert.NoError(t, err)
+
+	_, err = version.ParseWith("1.0.0a1", version.WithMaxVersionLength(5))
+	assert.ErrorIs(t, err, version.ErrInputTooLong)
+
+	_, err = version.ParseWith("1.0.0a1", version.WithMaxVersionLength(0))
+	assert.NoError(t, err)
+}
+
+var footprintVersions = []string{"1.2.3", "2!1.0.0rc1", "1.0.post2.dev3", "3.10.1+ubuntu-1", "0.1a1"}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = version.Parse(footprintVersions[i%len(footprintVersions)])
+	}
+}
+
+// BenchmarkVersion_Footprint reports the heap retained by each parsed version.
+func BenchmarkVersion_Footprint(b *testing.B) {
+	const n = 100000
+	for i := 0; i < b.N; i++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		versions := make([]version.Version, n)
+		for j := range versions {
+			versions[j] = version.MustParse(footprintVersions[j%len(footprintVersions)])
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/n, "B/version")
+		runtime.KeepAlive(versions)
+	}
+}
@@ -0,0 +1,146 @@
+// Package versionbson provides BSON support for go-pep440-version, so that versions and specifiers can be stored
+// in MongoDB documents as plain strings. It is a separate module so that the MongoDB driver isn't a dependency of
+// go-pep440-version.
+package versionbson
+
+import (
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+var (
+	tVersion    = reflect.TypeOf(version.Version{})
+	tSpecifiers = reflect.TypeOf(version.Specifiers{})
+)
+
+// Version wraps version.Version to implement bson.ValueMarshaler and bson.ValueUnmarshaler.
+type Version struct {
+	version.Version
+}
+
+// MarshalBSONValue implements [bson.ValueMarshaler]. The zero Version is stored as null.
+func (v Version) MarshalBSONValue() (byte, []byte, error) {
+	text, err := v.MarshalText()
+	if err != nil {
+		return 0, nil, err
+	}
+	return marshalString(string(text))
+}
+
+// UnmarshalBSONValue implements [bson.ValueUnmarshaler].
+func (v *Version) UnmarshalBSONValue(typ byte, data []byte) error {
+	s, err := unmarshalString(typ, data)
+	if err != nil || s == "" {
+		v.Version = version.Version{}
+		return err
+	}
+	return v.Version.UnmarshalText([]byte(s))
+}
+
+// Specifiers wraps version.Specifiers to implement bson.ValueMarshaler and bson.ValueUnmarshaler.
+type Specifiers struct {
+	version.Specifiers
+}
+
+// MarshalBSONValue implements [bson.ValueMarshaler]. The zero Specifiers is stored as null.
+func (ss Specifiers) MarshalBSONValue() (byte, []byte, error) {
+	return marshalString(ss.String())
+}
+
+// UnmarshalBSONValue implements [bson.ValueUnmarshaler].
+func (ss *Specifiers) UnmarshalBSONValue(typ byte, data []byte) error {
+	s, err := unmarshalString(typ, data)
+	if err != nil || s == "" {
+		ss.Specifiers = version.Specifiers{}
+		return err
+	}
+	return ss.Specifiers.UnmarshalText([]byte(s))
+}
+
+// Register registers codecs for version.Version and version.Specifiers,
+// so that they can be used in documents directly without the wrapper types.
+func Register(r *bson.Registry) {
+	r.RegisterTypeEncoder(tVersion, bson.ValueEncoderFunc(encodeValue))
+	r.RegisterTypeDecoder(tVersion, bson.ValueDecoderFunc(decodeValue))
+	r.RegisterTypeEncoder(tSpecifiers, bson.ValueEncoderFunc(encodeValue))
+	r.RegisterTypeDecoder(tSpecifiers, bson.ValueDecoderFunc(decodeValue))
+}
+
+// NewRegistry returns the default registry with the codecs of this package registered.
+func NewRegistry() *bson.Registry {
+	r := bson.NewRegistry()
+	Register(r)
+	return r
+}
+
+func encodeValue(_ bson.EncodeContext, vw bson.ValueWriter, val reflect.Value) error {
+	var s string
+	switch v := val.Interface().(type) {
+	case version.Version:
+		text, err := v.MarshalText()
+		if err != nil {
+			return err
+		}
+		s = string(text)
+	case version.Specifiers:
+		s = v.String()
+	default:
+		return bson.ValueEncoderError{Name: "encodeValue", Types: []reflect.Type{tVersion, tSpecifiers}, Received: val}
+	}
+
+	if s == "" {
+		return vw.WriteNull()
+	}
+	return vw.WriteString(s)
+}
+
+func decodeValue(_ bson.DecodeContext, vr bson.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || (val.Type() != tVersion && val.Type() != tSpecifiers) {
+		return bson.ValueDecoderError{Name: "decodeValue", Types: []reflect.Type{tVersion, tSpecifiers}, Received: val}
+	}
+
+	var s string
+	switch vr.Type() {
+	case bson.TypeNull:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+	case bson.TypeString:
+		var err error
+		if s, err = vr.ReadString(); err != nil {
+			return err
+		}
+	default:
+		return xerrors.Errorf("cannot decode %v into %s", vr.Type(), val.Type())
+	}
+
+	val.Set(reflect.Zero(val.Type()))
+	if s == "" {
+		return nil
+	}
+	return val.Addr().Interface().(interface{ UnmarshalText([]byte) error }).UnmarshalText([]byte(s))
+}
+
+func marshalString(s string) (byte, []byte, error) {
+	if s == "" {
+		return byte(bson.TypeNull), nil, nil
+	}
+	typ, data, err := bson.MarshalValue(s)
+	return byte(typ), data, err
+}
+
+func unmarshalString(typ byte, data []byte) (string, error) {
+	rv := bson.RawValue{Type: bson.Type(typ), Value: data}
+	if rv.Type == bson.TypeNull {
+		return "", nil
+	}
+	s, ok := rv.StringValueOK()
+	if !ok {
+		return "", xerrors.Errorf("cannot decode %v as a string", rv.Type)
+	}
+	return s, nil
+}
@@ -0,0 +1,82 @@
+package versionbson_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+
+	"github.com/aquasecurity/go-pep440-version"
+	"github.com/aquasecurity/go-pep440-version/versionbson"
+)
+
+func TestWrappers(t *testing.T) {
+	type advisory struct {
+		Affected versionbson.Specifiers `bson:"affected"`
+		Fixed    versionbson.Version    `bson:"fixed"`
+		Patched  versionbson.Version    `bson:"patched"`
+	}
+
+	affected, err := version.NewSpecifiers(">=1.0,<1.4.2||==2.0")
+	require.NoError(t, err)
+
+	data, err := bson.Marshal(advisory{
+		Affected: versionbson.Specifiers{Specifiers: affected},
+		Fixed:    versionbson.Version{Version: version.MustParse("1.4.2")},
+	})
+	require.NoError(t, err)
+
+	var raw bson.M
+	require.NoError(t, bson.Unmarshal(data, &raw))
+	assert.Equal(t, bson.M{"affected": ">=1.0,<1.4.2||==2.0", "fixed": "1.4.2", "patched": nil}, raw)
+
+	var got advisory
+	require.NoError(t, bson.Unmarshal(data, &got))
+	assert.Equal(t, affected.String(), got.Affected.String())
+	assert.Equal(t, version.MustParse("1.4.2"), got.Fixed.Version)
+	assert.Equal(t, version.Version{}, got.Patched.Version)
+
+	t.Run("invalid", func(t *testing.T) {
+		data, err := bson.Marshal(bson.M{"fixed": "french toast"})
+		require.NoError(t, err)
+		assert.ErrorIs(t, bson.Unmarshal(data, &got), version.ErrInvalidVersion)
+
+		data, err = bson.Marshal(bson.M{"affected": 42})
+		require.NoError(t, err)
+		assert.Error(t, bson.Unmarshal(data, &got))
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	type advisory struct {
+		Affected version.Specifiers `bson:"affected"`
+		Fixed    version.Version    `bson:"fixed"`
+		Patched  version.Version    `bson:"patched"`
+	}
+
+	affected, err := version.NewSpecifiers(">=1.0,<1.4.2||==2.0")
+	require.NoError(t, err)
+	want := advisory{
+		Affected: affected,
+		Fixed:    version.MustParse("1.4.2"),
+	}
+
+	buf := new(bytes.Buffer)
+	enc := bson.NewEncoder(bson.NewDocumentWriter(buf))
+	enc.SetRegistry(versionbson.NewRegistry())
+	require.NoError(t, enc.Encode(want))
+
+	var raw bson.M
+	require.NoError(t, bson.Unmarshal(buf.Bytes(), &raw))
+	assert.Equal(t, bson.M{"affected": ">=1.0,<1.4.2||==2.0", "fixed": "1.4.2", "patched": nil}, raw)
+
+	var got advisory
+	dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(buf.Bytes())))
+	dec.SetRegistry(versionbson.NewRegistry())
+	require.NoError(t, dec.Decode(&got))
+	assert.Equal(t, want.Affected.String(), got.Affected.String())
+	assert.Equal(t, want.Fixed, got.Fixed)
+	assert.Equal(t, want.Patched, got.Patched)
+}
@@ -0,0 +1,19 @@
+module github.com/aquasecurity/go-pep440-version/versionbson
+
+go 1.22.11
+
+toolchain go1.23.4
+
+require (
+	github.com/aquasecurity/go-pep440-version v0.1.0
+	github.com/stretchr/testify v1.10.0
+	go.mongodb.org/mongo-driver/v2 v2.8.0
+	golang.org/x/xerrors v0.0.0-20231012003039-104605ab7028
+)
+
+require (
+	github.com/aquasecurity/go-version v0.0.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
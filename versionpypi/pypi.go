@@ -0,0 +1,107 @@
+// Package versionpypi provides a small client of the PyPI JSON API listing the released versions of a project.
+// It lives in a separate package to keep network access out of go-pep440-version.
+package versionpypi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+// DefaultBaseURL is the base URL of the JSON API of PyPI.
+const DefaultBaseURL = "https://pypi.org/pypi"
+
+// ErrNotFound is returned when the index has no project of the name.
+var ErrNotFound = xerrors.New("project not found")
+
+// Client fetches the releases of projects from the JSON API of PyPI or of an index compatible with it.
+// The zero Client uses http.DefaultClient and DefaultBaseURL.
+type Client struct {
+	// HTTPClient sends the requests. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+	// BaseURL is the base URL of the JSON API, e.g. "https://pypi.org/pypi". If empty, DefaultBaseURL is used.
+	BaseURL string
+}
+
+// InvalidRelease is a release key which isn't a valid PEP 440 version, e.g. "1.0.x" of an old project.
+type InvalidRelease struct {
+	Release string
+	Err     error
+}
+
+// Releases is the releases of a project.
+type Releases struct {
+	// Versions are the valid versions in ascending order.
+	Versions version.Collection
+	// Invalid are the release keys skipped as they aren't valid versions, sorted by the keys.
+	Invalid []InvalidRelease
+}
+
+// Releases fetches <BaseURL>/<name>/json and parses its release keys. It returns ErrNotFound if the index responds
+// with 404 Not Found, and an error for other failures of the request or a malformed response.
+func (c Client) Releases(ctx context.Context, name string) (Releases, error) {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(baseURL, "/")+"/"+url.PathEscape(name)+"/json", http.NoBody)
+	if err != nil {
+		return Releases{}, xerrors.Errorf("releases of %s: %w", name, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Releases{}, xerrors.Errorf("releases of %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return Releases{}, xerrors.Errorf("releases of %s: %w", name, ErrNotFound)
+	case resp.StatusCode != http.StatusOK:
+		return Releases{}, xerrors.Errorf("releases of %s: unexpected status %s", name, resp.Status)
+	}
+
+	var project struct {
+		Releases map[string]json.RawMessage `json:"releases"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return Releases{}, xerrors.Errorf("releases of %s: %w", name, err)
+	}
+	return parseReleases(project.Releases), nil
+}
+
+// FetchReleases fetches the releases of a project from PyPI with the zero Client.
+func FetchReleases(ctx context.Context, name string) (Releases, error) {
+	return Client{}.Releases(ctx, name)
+}
+
+func parseReleases(releases map[string]json.RawMessage) Releases {
+	var r Releases
+	for key := range releases {
+		v, err := version.Parse(key)
+		if err != nil {
+			r.Invalid = append(r.Invalid, InvalidRelease{Release: key, Err: err})
+			continue
+		}
+		r.Versions = append(r.Versions, v)
+	}
+	r.Versions.Sort()
+	slices.SortFunc(r.Invalid, func(a, b InvalidRelease) int {
+		return strings.Compare(a.Release, b.Release)
+	})
+	return r
+}
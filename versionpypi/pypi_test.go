@@ -0,0 +1,75 @@
+package versionpypi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+	"github.com/aquasecurity/go-pep440-version/versionpypi"
+)
+
+func TestClient_Releases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pypi/requests/json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{
+  "info": {"name": "requests", "version": "2.31.0"},
+  "releases": {
+    "2.31.0": [{"filename": "requests-2.31.0.tar.gz"}],
+    "0.2.0": [],
+    "2.0.0rc1": [],
+    "2.9.1": [],
+    "2.10.0": [],
+    "1.0.x": [],
+    "latest": []
+  }
+}`))
+		case "/pypi/broken/json":
+			_, _ = w.Write([]byte(`{"releases": [`))
+		case "/pypi/unavailable/json":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := versionpypi.Client{HTTPClient: server.Client(), BaseURL: server.URL + "/pypi/"}
+
+	t.Run("releases", func(t *testing.T) {
+		got, err := client.Releases(context.Background(), "requests")
+		require.NoError(t, err)
+
+		var versions []string
+		for _, v := range got.Versions {
+			versions = append(versions, v.String())
+		}
+		assert.Equal(t, []string{"0.2.0", "2.0.0rc1", "2.9.1", "2.10.0", "2.31.0"}, versions)
+
+		require.Len(t, got.Invalid, 2)
+		assert.Equal(t, "1.0.x", got.Invalid[0].Release)
+		assert.ErrorIs(t, got.Invalid[0].Err, version.ErrInvalidVersion)
+		assert.Equal(t, "latest", got.Invalid[1].Release)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.Releases(context.Background(), "missing")
+		require.ErrorIs(t, err, versionpypi.ErrNotFound)
+	})
+
+	t.Run("unexpected status", func(t *testing.T) {
+		_, err := client.Releases(context.Background(), "unavailable")
+		require.ErrorContains(t, err, "503")
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		_, err := client.Releases(context.Background(), "broken")
+		require.Error(t, err)
+	})
+}
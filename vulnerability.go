@@ -0,0 +1,224 @@
+package version
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// FromAffected returns compact specifiers satisfied by exactly the affected versions among the releases,
+// e.g. ">=1.2,<1.4.3" for the releases 1.0, 1.2, 1.4, 1.4.3 and 1.5 where 1.2 and 1.4 are affected.
+// The releases needn't be sorted. An unaffected release in between affected ones is excluded with !=,
+// a single affected release in between unaffected ones is matched with ==, and the range is open below the
+// first release and above the last one if they are affected. It returns ErrInvalidVersion if an affected
+// version isn't one of the releases and ErrUnrepresentable if the specifiers can't tell the affected
+// releases apart from the others, e.g. for local versions.
+func FromAffected(releases, affected []Version) (Specifiers, error) {
+	sorted := slices.Clone(releases)
+	slices.SortFunc(sorted, Version.Compare)
+	sorted = slices.CompactFunc(sorted, Version.Equal)
+
+	hit := make([]bool, len(sorted))
+	for _, v := range affected {
+		i, found := slices.BinarySearchFunc(sorted, v, Version.Compare)
+		if !found {
+			return Specifiers{}, xerrors.Errorf("affected version %s is not a release: %w", v, ErrInvalidVersion)
+		}
+		hit[i] = true
+	}
+
+	var intervals []Interval
+	for i := 0; i < len(sorted); i++ {
+		if !hit[i] {
+			continue
+		}
+		j := i
+		for j+1 < len(sorted) && hit[j+1] {
+			j++
+		}
+		intervals = append(intervals, affectedInterval(sorted, hit, i, j))
+		i = j
+	}
+
+	ss, err := FromRanges(intervals)
+	if err != nil {
+		return Specifiers{}, xerrors.Errorf("affected versions %v: %w", affected, err)
+	}
+	for i, v := range sorted {
+		if ss.Check(v) != hit[i] {
+			return Specifiers{}, xerrors.Errorf("affected versions %v can't be told apart from %s: %w",
+				affected, v, ErrUnrepresentable)
+		}
+	}
+	return ss, nil
+}
+
+// affectedInterval returns the interval of the affected releases from i to j.
+// A single unaffected release in between two runs of affected ones is excluded from both intervals alone,
+// so that FromRanges writes it with !=.
+func affectedInterval(sorted []Version, hit []bool, i, j int) Interval {
+	var interval Interval
+	lowerGap, upperGap := i >= 2 && hit[i-2], j+2 < len(sorted) && hit[j+2]
+	switch {
+	case i == 0:
+		interval.Lower = Bound{Unbounded: true}
+	case lowerGap:
+		interval.Lower = Bound{Version: sorted[i-1]}
+	default:
+		interval.Lower = Bound{Version: sorted[i], Inclusive: true}
+	}
+
+	switch {
+	case j == len(sorted)-1:
+		interval.Upper = Bound{Unbounded: true}
+	case upperGap:
+		interval.Upper = Bound{Version: sorted[j+1]}
+	case i == j && i > 0 && !lowerGap:
+		interval.Upper = Bound{Version: sorted[j], Inclusive: true}
+	default:
+		// Written as <2.0 excluding the unlisted pre-releases of 2.0 unless they could be affected
+		next := sorted[j+1]
+		if start := withDev0(next); !next.IsPreRelease() && sorted[j].Compare(start) < 0 {
+			next = start
+		}
+		interval.Upper = Bound{Version: next}
+	}
+	return interval
+}
+
+// VulnerabilityRange is the versions of a package affected by a vulnerability, as published in an advisory.
+type VulnerabilityRange struct {
+	// Affected are the specifiers of the affected versions, which are affected if they satisfy any of them.
+	// If it is empty, the versions lower than every fixed version are affected.
+	Affected []Specifiers
+	// Fixed are the versions the vulnerability is fixed in, if known, e.g. one per maintained release series.
+	Fixed []Version
+}
+
+// Match reports whether the version is affected, and if so, the fixed versions above it which aren't affected
+// themselves in ascending order, so that the first one is the smallest upgrade fixing the vulnerability.
+func (r VulnerabilityRange) Match(v Version) (matched bool, fixedIn []Version) {
+	if !r.affects(v) {
+		return false, nil
+	}
+	for _, f := range r.Fixed {
+		if f.GreaterThan(v) && !r.affects(f) && !slices.ContainsFunc(fixedIn, f.Equal) {
+			fixedIn = append(fixedIn, f)
+		}
+	}
+	slices.SortFunc(fixedIn, Version.Compare)
+	return true, fixedIn
+}
+
+// FirstUnaffected returns the smallest final release of the candidates which satisfies none of the affected ranges,
+// i.e. the fixed version to recommend. ok is false if there is no such version, unless WithPreReleaseFallback is
+// set and a pre-release or development release is unaffected, in which case it returns the smallest of them.
+func FirstUnaffected(candidates []Version, affected []Specifiers, opts ...LatestOption) (first Version, ok bool) {
+	var c latestConf
+	for _, o := range opts {
+		o.apply(&c)
+	}
+
+	var firstPre Version
+	var okPre bool
+	for _, v := range candidates {
+		if slices.ContainsFunc(affected, func(ss Specifiers) bool { return ss.Check(v) }) {
+			continue
+		}
+		switch {
+		case v.IsPreRelease():
+			if !okPre || v.LessThan(firstPre) {
+				firstPre, okPre = v, true
+			}
+		case !ok || v.LessThan(first):
+			first, ok = v, true
+		}
+	}
+	if !ok && c.preReleaseFallback {
+		return firstPre, okPre
+	}
+	return first, ok
+}
+
+func (r VulnerabilityRange) affects(v Version) bool {
+	if len(r.Affected) == 0 {
+		// 1.2rc1 is affected if 1.2 is the fix though <1.2 excludes it
+		return len(r.Fixed) > 0 && !slices.ContainsFunc(r.Fixed, v.GreaterThanOrEqual)
+	}
+	return slices.ContainsFunc(r.Affected, func(ss Specifiers) bool {
+		return ss.Check(v)
+	})
+}
+
+// Explanation is why a version matched the ranges of an advisory or not, as returned by Match.
+type Explanation struct {
+	Version Version
+	// Range is the index of the first range the version satisfies, or -1 if it satisfies none.
+	Range int
+	// Clauses are the clauses of the first OR group of the range satisfied by the version. It is empty if Range is -1.
+	Clauses []ClauseResult
+	// Rejections are the clauses rejecting the version in each range with their reasons as returned by CheckDetail,
+	// if the version satisfies no range.
+	Rejections [][]ClauseResult
+}
+
+// Match reports whether the version satisfies any of the ranges of an advisory, and explains which range and
+// clauses it matched, or why each range rejected it.
+func Match(v Version, ranges []Specifiers) (bool, Explanation) {
+	e := Explanation{Version: v, Range: -1}
+	for i, ss := range ranges {
+		ok, results := ss.CheckDetail(v)
+		if !ok {
+			e.Rejections = append(e.Rejections, slices.DeleteFunc(results, func(r ClauseResult) bool {
+				return r.Satisfied
+			}))
+			continue
+		}
+
+		for group := range ss.specifiers {
+			clauses := slices.DeleteFunc(slices.Clone(results), func(r ClauseResult) bool {
+				return r.Group != group
+			})
+			if !slices.ContainsFunc(clauses, func(r ClauseResult) bool { return !r.Satisfied }) {
+				e.Range, e.Clauses, e.Rejections = i, clauses, nil
+				return true, e
+			}
+		}
+	}
+	return false, e
+}
+
+// String returns the explanation in a sentence, e.g. "1.3.2 matched '>=1.0, <1.4' from range #2" with the ranges
+// numbered from 1, or "1.5 matched none of the ranges: range #1 rejected by '<1.4' (not lower than 1.4)".
+func (e Explanation) String() string {
+	if e.Range >= 0 {
+		return fmt.Sprintf("%s matched '%s' from range #%d", e.Version, joinClauses(e.Clauses), e.Range+1)
+	} else if len(e.Rejections) == 0 {
+		return e.Version.String() + " matched no ranges"
+	}
+
+	reasons := make([]string, len(e.Rejections))
+	for i, rejection := range e.Rejections {
+		if len(rejection) == 0 {
+			reasons[i] = fmt.Sprintf("range #%d is empty", i+1)
+			continue
+		}
+		clauses := make([]string, len(rejection))
+		for j, r := range rejection {
+			clauses[j] = fmt.Sprintf("'%s' (%s)", r.Specifier, r.Reason)
+		}
+		reasons[i] = fmt.Sprintf("range #%d rejected by %s", i+1, strings.Join(clauses, ", "))
+	}
+	return e.Version.String() + " matched none of the ranges: " + strings.Join(reasons, "; ")
+}
+
+// joinClauses returns the clauses as written in specifiers, e.g. ">=1.0, <1.4".
+func joinClauses(results []ClauseResult) string {
+	clauses := make([]string, len(results))
+	for i, r := range results {
+		clauses[i] = r.Specifier.String()
+	}
+	return strings.Join(clauses, ", ")
+}
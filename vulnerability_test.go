@@ -0,0 +1,343 @@
+package version
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var affectedReleases = []string{
+	"0.9", "1.0", "1.1", "1.2rc1", "1.2", "1.2.1", "1.3", "1.4", "1.4.3", "1.5", "2.0.dev1", "2.0rc1", "2.0", "2.0.post1", "3.0",
+}
+
+func TestFromAffected(t *testing.T) {
+	tests := []struct {
+		name     string
+		releases []string
+		affected []string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "contiguous",
+			releases: []string{"1.0", "1.2", "1.4", "1.4.3", "1.5"},
+			affected: []string{"1.2", "1.4"},
+			want:     ">=1.2,<1.4.3",
+		},
+		{
+			name:     "unsorted",
+			releases: []string{"1.5", "1.4.3", "1.0", "1.4", "1.2"},
+			affected: []string{"1.4", "1.2"},
+			want:     ">=1.2,<1.4.3",
+		},
+		{
+			name:     "from the first release",
+			releases: affectedReleases,
+			affected: []string{"0.9", "1.0", "1.1"},
+			want:     "<1.2rc1",
+		},
+		{
+			name:     "up to the last release",
+			releases: affectedReleases,
+			affected: []string{"2.0.post1", "3.0"},
+			want:     ">=2.0.post1",
+		},
+		{
+			name:     "gap",
+			releases: affectedReleases,
+			affected: []string{"1.1", "1.2rc1", "1.2.1", "1.3"},
+			want:     ">=1.1,<1.4,!=1.2",
+		},
+		{
+			name:     "single release",
+			releases: affectedReleases,
+			affected: []string{"1.3"},
+			want:     "==1.3",
+		},
+		{
+			name:     "pre-releases",
+			releases: affectedReleases,
+			affected: []string{"1.5", "2.0.dev1", "2.0rc1"},
+			want:     ">=1.5,<2.0",
+		},
+		{
+			name:     "disjoint",
+			releases: affectedReleases,
+			affected: []string{"1.0", "1.4", "1.4.3"},
+			want:     "==1.0||>=1.4,<1.5",
+		},
+		{
+			name:     "unknown release",
+			releases: affectedReleases,
+			affected: []string{"1.6"},
+			wantErr:  ErrInvalidVersion,
+		},
+		{
+			name:     "nothing affected",
+			releases: affectedReleases,
+			wantErr:  ErrUnrepresentable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			releases, err := ParseAll(tt.releases)
+			require.NoError(t, err)
+			affected, err := ParseAll(tt.affected)
+			require.NoError(t, err)
+
+			got, err := FromAffected(releases, affected)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestFromAffected_Exact(t *testing.T) {
+	releases, err := ParseAll(affectedReleases)
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewSource(1))
+	for range 1000 {
+		var affected []Version
+		for _, v := range releases {
+			if r.Intn(2) == 0 {
+				affected = append(affected, v)
+			}
+		}
+		if len(affected) == 0 {
+			continue
+		}
+
+		got, err := FromAffected(releases, affected)
+		require.NoError(t, err, "%v", affected)
+		for _, v := range releases {
+			want := slices.ContainsFunc(affected, v.Equal)
+			require.Equal(t, want, got.Check(v), "%s for %v: %s", got, affected, v)
+		}
+	}
+}
+
+func TestVulnerabilityRange_Match(t *testing.T) {
+	specifiers := func(s string, opts ...SpecifierOption) Specifiers {
+		ss, err := NewSpecifiers(s, opts...)
+		require.NoError(t, err)
+		return ss
+	}
+	branches := VulnerabilityRange{
+		Affected: []Specifiers{specifiers(">=1.0,<1.2.5"), specifiers(">=2.0,<2.0.1")},
+		Fixed:    []Version{MustParse("2.0.1"), MustParse("1.2.5"), MustParse("1.2.5.0")},
+	}
+	tests := []struct {
+		name    string
+		r       VulnerabilityRange
+		version string
+		want    bool
+		fixedIn []string
+	}{
+		{name: "old branch", r: branches, version: "1.1", want: true, fixedIn: []string{"1.2.5", "2.0.1"}},
+		{name: "new branch", r: branches, version: "2.0", want: true, fixedIn: []string{"2.0.1"}},
+		{name: "fixed", r: branches, version: "1.2.5", want: false},
+		{name: "in between", r: branches, version: "1.5", want: false},
+		{name: "local version", r: branches, version: "2.0.1+ubuntu1", want: false},
+		{name: "unaffected branch", r: branches, version: "0.9", want: false},
+		{
+			name:    "fix affected again",
+			r:       VulnerabilityRange{Affected: []Specifiers{specifiers("<2.0 || ==2.1")}, Fixed: []Version{MustParse("2.1"), MustParse("2.2")}},
+			version: "1.0",
+			want:    true,
+			fixedIn: []string{"2.2"},
+		},
+		{
+			name:    "fixed versions only",
+			r:       VulnerabilityRange{Fixed: []Version{MustParse("1.2")}},
+			version: "1.2rc1",
+			want:    true,
+			fixedIn: []string{"1.2"},
+		},
+		{name: "above fixed versions", r: VulnerabilityRange{Fixed: []Version{MustParse("1.2")}}, version: "1.2.post1"},
+		{
+			name:    "unknown fix",
+			r:       VulnerabilityRange{Affected: []Specifiers{specifiers(">=1.0")}},
+			version: "3.0",
+			want:    true,
+		},
+		{name: "nothing known", version: "1.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, fixedIn := tt.r.Match(MustParse(tt.version))
+			assert.Equal(t, tt.want, got)
+
+			var fixed []string
+			for _, f := range fixedIn {
+				fixed = append(fixed, f.String())
+			}
+			assert.Equal(t, tt.fixedIn, fixed)
+		})
+	}
+}
+
+func TestFirstUnaffected(t *testing.T) {
+	var affected []Specifiers
+	for _, s := range []string{">=1.0, <1.2.5", ">=2.0, <2.0.1 || ==2.1"} {
+		ss, err := NewSpecifiers(s)
+		require.NoError(t, err)
+		affected = append(affected, ss)
+	}
+	tests := []struct {
+		name       string
+		candidates []string
+		opts       []LatestOption
+		want       string
+		wantOK     bool
+	}{
+		{
+			name:       "smallest fix",
+			candidates: []string{"2.0.1", "1.2.4", "1.2.5", "1.3", "1.2rc1"},
+			want:       "1.2.5",
+			wantOK:     true,
+		},
+		{
+			name:       "older than affected",
+			candidates: []string{"0.9", "1.3"},
+			want:       "0.9",
+			wantOK:     true,
+		},
+		{
+			name:       "pre-release skipped",
+			candidates: []string{"2.0.1rc1", "2.0.1", "2.1"},
+			want:       "2.0.1",
+			wantOK:     true,
+		},
+		{
+			name:       "pre-releases only",
+			candidates: []string{"2.0", "2.2rc1", "2.2.dev1"},
+		},
+		{
+			name:       "pre-release fallback",
+			candidates: []string{"2.0", "2.2rc1", "2.2.dev1"},
+			opts:       []LatestOption{WithPreReleaseFallback(true)},
+			want:       "2.2.dev1",
+			wantOK:     true,
+		},
+		{
+			name:       "final release over pre-release fallback",
+			candidates: []string{"1.2.5rc1", "2.2"},
+			opts:       []LatestOption{WithPreReleaseFallback(true)},
+			want:       "2.2",
+			wantOK:     true,
+		},
+		{
+			name:       "all affected",
+			candidates: []string{"1.0", "2.0", "2.1"},
+			opts:       []LatestOption{WithPreReleaseFallback(true)},
+		},
+		{name: "no candidates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var candidates []Version
+			for _, c := range tt.candidates {
+				candidates = append(candidates, MustParse(c))
+			}
+			got, ok := FirstUnaffected(candidates, affected, tt.opts...)
+			require.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	var ranges []Specifiers
+	for _, s := range []string{"<0.9", ">=1.0, <1.4 || ==2.*"} {
+		ss, err := NewSpecifiers(s)
+		require.NoError(t, err)
+		ranges = append(ranges, ss)
+	}
+	ranges = append(ranges, Specifiers{})
+	deny, err := NewSpecifiers(">=1.0, <1.4 || ==2.*", WithPreReleasePolicy(PreReleaseDeny))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		ranges  []Specifiers
+		version string
+		want    bool
+		range_  int
+		clauses []string
+		explain string
+	}{
+		{
+			name:    "first group",
+			ranges:  ranges,
+			version: "1.3.2",
+			want:    true,
+			range_:  1,
+			clauses: []string{">=1.0", "<1.4"},
+			explain: "1.3.2 matched '>=1.0, <1.4' from range #2",
+		},
+		{
+			name:    "second group",
+			ranges:  ranges,
+			version: "2.1",
+			want:    true,
+			range_:  1,
+			clauses: []string{"==2.*"},
+			explain: "2.1 matched '==2.*' from range #2",
+		},
+		{
+			name:    "first range",
+			ranges:  ranges,
+			version: "0.8",
+			want:    true,
+			clauses: []string{"<0.9"},
+			explain: "0.8 matched '<0.9' from range #1",
+		},
+		{
+			name:    "no match",
+			ranges:  ranges,
+			version: "1.5",
+			range_:  -1,
+			explain: "1.5 matched none of the ranges: range #1 rejected by '<0.9' (not lower than 0.9); " +
+				"range #2 rejected by '<1.4' (not lower than 1.4), '==2.*' (does not match 2.*); range #3 is empty",
+		},
+		{
+			name:    "pre-release",
+			ranges:  []Specifiers{deny},
+			version: "1.2rc1",
+			range_:  -1,
+			explain: "1.2rc1 matched none of the ranges: range #1 rejected by '>=1.0' (pre-release excluded), " +
+				"'<1.4' (pre-release excluded), '==2.*' (does not match 2.*)",
+		},
+		{name: "no ranges", version: "1.0", range_: -1, explain: "1.0 matched no ranges"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, e := Match(MustParse(tt.version), tt.ranges)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.range_, e.Range)
+			assert.Equal(t, tt.explain, e.String())
+
+			var clauses []string
+			for _, c := range e.Clauses {
+				assert.True(t, c.Satisfied)
+				clauses = append(clauses, c.Specifier.String())
+			}
+			assert.Equal(t, tt.clauses, clauses)
+			if got {
+				assert.Empty(t, e.Rejections)
+			} else {
+				assert.Len(t, e.Rejections, len(tt.ranges))
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package version
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Wheel is the information in the filename of a wheel (PEP 427), e.g. "requests-2.31.0-py3-none-any.whl".
+type Wheel struct {
+	// Name is the distribution name as escaped in the filename, e.g. "zope_interface".
+	Name string
+	// Version is the version of the distribution.
+	Version Version
+	// BuildTag is the optional build number breaking ties between wheels of the same version, e.g. "1" or "2foo".
+	BuildTag string
+	// PythonTags, ABITags and PlatformTags are the compatibility tags (PEP 425) with the compressed tag sets
+	// expanded, e.g. ["py2", "py3"] for "py2.py3".
+	PythonTags   []string
+	ABITags      []string
+	PlatformTags []string
+}
+
+// ParseWheelFilename parses the filename of a wheel like "name-1.2.3-py3-none-any.whl" with the version parsed like
+// ParseWith. It returns ErrInvalidFilename if the filename doesn't consist of the parts of PEP 427, or the error of
+// ParseWith for the version.
+func ParseWheelFilename(filename string, opts ...ParseOption) (Wheel, error) {
+	base, ok := strings.CutSuffix(filename, ".whl")
+	if !ok {
+		return Wheel{}, xerrors.Errorf("%q is not a wheel: %w", filename, ErrInvalidFilename)
+	}
+	parts := strings.Split(base, "-")
+	if len(parts) != 5 && len(parts) != 6 {
+		return Wheel{}, xerrors.Errorf("%q has %d parts: %w", filename, len(parts), ErrInvalidFilename)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return Wheel{}, xerrors.Errorf("%q has an empty part: %w", filename, ErrInvalidFilename)
+		}
+	}
+
+	v, err := ParseWith(parts[1], opts...)
+	if err != nil {
+		return Wheel{}, xerrors.Errorf("wheel %q: %w", filename, err)
+	}
+	w := Wheel{Name: parts[0], Version: v}
+	if len(parts) == 6 {
+		if w.BuildTag = parts[2]; w.BuildTag[0] < '0' || w.BuildTag[0] > '9' {
+			return Wheel{}, xerrors.Errorf("%q has the build tag %q not starting with a digit: %w",
+				filename, w.BuildTag, ErrInvalidFilename)
+		}
+	}
+
+	tags := parts[len(parts)-3:]
+	w.PythonTags = strings.Split(tags[0], ".")
+	w.ABITags = strings.Split(tags[1], ".")
+	w.PlatformTags = strings.Split(tags[2], ".")
+	return w, nil
+}
@@ -0,0 +1,63 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWheelFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     Wheel
+		wantErr  error
+	}{
+		{
+			filename: "requests-2.31.0-py3-none-any.whl",
+			want: Wheel{
+				Name: "requests", Version: MustParse("2.31.0"),
+				PythonTags: []string{"py3"}, ABITags: []string{"none"}, PlatformTags: []string{"any"},
+			},
+		},
+		{
+			filename: "six-1.16.0-py2.py3-none-any.whl",
+			want: Wheel{
+				Name: "six", Version: MustParse("1.16.0"),
+				PythonTags: []string{"py2", "py3"}, ABITags: []string{"none"}, PlatformTags: []string{"any"},
+			},
+		},
+		{
+			filename: "zope_interface-6.0-1-cp311-cp311-manylinux_2_17_x86_64.manylinux2014_x86_64.whl",
+			want: Wheel{
+				Name: "zope_interface", Version: MustParse("6.0"), BuildTag: "1",
+				PythonTags: []string{"cp311"}, ABITags: []string{"cp311"},
+				PlatformTags: []string{"manylinux_2_17_x86_64", "manylinux2014_x86_64"},
+			},
+		},
+		{
+			filename: "pkg-1.0rc1+local.1-2foo-py3-none-any.whl",
+			want: Wheel{
+				Name: "pkg", Version: MustParse("1.0rc1+local.1"), BuildTag: "2foo",
+				PythonTags: []string{"py3"}, ABITags: []string{"none"}, PlatformTags: []string{"any"},
+			},
+		},
+		{filename: "requests-2.31.0.tar.gz", wantErr: ErrInvalidFilename},
+		{filename: "requests-2.31.0-py3-any.whl", wantErr: ErrInvalidFilename},
+		{filename: "requests-2.31.0-1-2-py3-none-any.whl", wantErr: ErrInvalidFilename},
+		{filename: "requests-2.31.0-foo-py3-none-any.whl", wantErr: ErrInvalidFilename},
+		{filename: "requests-2.31.0--none-any.whl", wantErr: ErrInvalidFilename},
+		{filename: "requests-latest-py3-none-any.whl", wantErr: ErrInvalidVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			got, err := ParseWheelFilename(tt.filename)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
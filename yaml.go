@@ -0,0 +1,38 @@
+package version
+
+// The YAML methods follow the interfaces of gopkg.in/yaml.v2 and gopkg.in/yaml.v3, which are matched by their
+// signatures, so that this package doesn't depend on either.
+
+// MarshalYAML implements the Marshaler interface of gopkg.in/yaml.v3, marshaling the version as a string.
+func (v Version) MarshalYAML() (any, error) {
+	b, err := v.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// UnmarshalYAML implements the obsolete Unmarshaler interface of gopkg.in/yaml.v3, unmarshaling the version from a
+// string.
+func (v *Version) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML implements the Marshaler interface of gopkg.in/yaml.v3, marshaling the specifiers as a string.
+func (ss Specifiers) MarshalYAML() (any, error) {
+	return ss.String(), nil
+}
+
+// UnmarshalYAML implements the obsolete Unmarshaler interface of gopkg.in/yaml.v3, unmarshaling the specifiers from
+// a string.
+func (ss *Specifiers) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return ss.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,63 @@
+package version_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/go-pep440-version"
+)
+
+// yamlScalar returns an unmarshal function like the one gopkg.in/yaml.v3 passes to UnmarshalYAML for a scalar.
+func yamlScalar(s string) func(any) error {
+	return func(out any) error {
+		p, ok := out.(*string)
+		if !ok {
+			return fmt.Errorf("cannot unmarshal !!str into %T", out)
+		}
+		*p = s
+		return nil
+	}
+}
+
+func TestYAML(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		var ss version.Specifiers
+		require.NoError(t, ss.UnmarshalYAML(yamlScalar(">= 1.0, < 1.4.2 || == 2.0")))
+		assert.Equal(t, ">= 1.0,< 1.4.2||== 2.0", ss.String())
+		assert.True(t, ss.Check(version.MustParse("1.4.1")))
+
+		var v version.Version
+		require.NoError(t, v.UnmarshalYAML(yamlScalar("v1.4.2")))
+		assert.Equal(t, "1.4.2", v.String())
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		ss, err := version.NewSpecifiers(">=1.0,<1.4.2||==2.0")
+		require.NoError(t, err)
+		got, err := ss.MarshalYAML()
+		require.NoError(t, err)
+		assert.Equal(t, ">=1.0,<1.4.2||==2.0", got)
+
+		got, err = version.MustParse("1.4.2").MarshalYAML()
+		require.NoError(t, err)
+		assert.Equal(t, "1.4.2", got)
+
+		_, err = version.Inf.MarshalYAML()
+		assert.ErrorIs(t, err, version.ErrInvalidVersion)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var ss version.Specifiers
+		assert.ErrorIs(t, ss.UnmarshalYAML(yamlScalar("=>1.0")), version.ErrInvalidSpecifier)
+
+		var v version.Version
+		assert.ErrorIs(t, v.UnmarshalYAML(yamlScalar("french toast")), version.ErrInvalidVersion)
+
+		errNode := errors.New("cannot unmarshal !!seq into string")
+		assert.ErrorIs(t, v.UnmarshalYAML(func(any) error { return errNode }), errNode)
+	})
+}